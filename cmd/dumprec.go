@@ -1,70 +1,324 @@
 package main
 
 import (
+	"bufio"
+	"encoding/json"
+	"flag"
 	"fmt"
 	"io"
 	"log"
 	"os"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	//
 	"github.com/gsiems/go-marc21-details/pkg/details"
 	"github.com/gsiems/go-marc21/pkg/marc21"
 )
 
+// fileList being the list of MARC files given by repeated -in flags
+type fileList []string
+
+func (l *fileList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *fileList) Set(s string) error {
+	*l = append(*l, s)
+	return nil
+}
+
+// ordinalRange being a range of record ordinals (first record is 1).
+// A zero last means "to the end of the file"
+type ordinalRange struct {
+	first int
+	last  int
+}
+
+// selector being the criteria used to select the records to dump. A
+// record is selected if it matches any of the criteria
+type selector struct {
+	all    bool
+	cns    map[string]bool
+	ranges []ordinalRange
+	re     *regexp.Regexp
+	tags   map[string]bool
+}
+
+// location being where a record was found
+type location struct {
+	file    string
+	ordinal int
+}
+
 func main() {
 
-	var marcfile, cn string
-	if len(os.Args) > 2 {
-		marcfile = os.Args[1]
-		cn = os.Args[2]
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		diffMain(os.Args[2:])
+		return
 	}
 
-	if marcfile == "" || cn == "" {
+	var format, cnfile, ranges, match, tags string
+	var files fileList
+	var sel selector
+
+	flag.StringVar(&format, "format", "text", "The output format (text or json).")
+	flag.Var(&files, "in", "A MARC file to read, - for stdin (may be repeated).")
+	flag.BoolVar(&sel.all, "all", false, "Dump all records.")
+	flag.StringVar(&cnfile, "cnfile", "", "A file of control numbers, one per line.")
+	flag.StringVar(&ranges, "range", "", "Record ordinal ranges, e.g. 1-10,25,40-")
+	flag.StringVar(&match, "match", "", "A regular expression to match against the control fields.")
+	flag.StringVar(&tags, "tags", "", "The control fields to match -match against, e.g. 003,008 (default all).")
+	flag.Usage = showHelp
+	flag.Parse()
+
+	args := flag.Args()
+	if len(files) == 0 && len(args) > 0 {
+		files = append(files, args[0])
+		args = args[1:]
+	}
+
+	sel.cns = make(map[string]bool)
+	for _, cn := range args {
+		sel.cns[cn] = true
+	}
+
+	if cnfile != "" {
+		err := readControlNumbers(cnfile, sel.cns)
+		if err != nil {
+			log.Fatal(fmt.Printf("File open failed: %q", err))
+		}
+	}
+
+	if ranges != "" {
+		var err error
+		sel.ranges, err = parseRanges(ranges)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if match != "" {
+		var err error
+		sel.re, err = regexp.Compile(match)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if tags != "" {
+			sel.tags = make(map[string]bool)
+			for _, t := range strings.Split(tags, ",") {
+				sel.tags[strings.TrimSpace(t)] = true
+			}
+		}
+	}
+
+	noSelection := !sel.all && len(sel.cns) == 0 && len(sel.ranges) == 0 && sel.re == nil
+	if len(files) == 0 || noSelection || (format != "text" && format != "json") {
 		showHelp()
 	}
 
-	fi, err := os.Open(marcfile)
-	if err != nil {
-		log.Fatal(fmt.Printf("File open failed: %q", err))
+	// Where each dumped record was first seen, by 003:001
+	seen := make(map[string]location)
+	found := make(map[string]bool)
+
+	for _, marcfile := range files {
+		dumpFile(marcfile, format, sel, seen, found)
 	}
-	defer func() {
-		if cerr := fi.Close(); cerr != nil && err == nil {
-			err = cerr
+
+	var missing []string
+	for cn := range sel.cns {
+		if !found[cn] {
+			missing = append(missing, cn)
 		}
-	}()
+	}
+	sort.Strings(missing)
+	for _, cn := range missing {
+		fmt.Fprintf(os.Stderr, "Not found: %s\n", cn)
+	}
+}
 
+// dumpFile dumps the selected records of a MARC file. Duplicate
+// records (same 001 and 003) are dumped and reported
+func dumpFile(marcfile, format string, sel selector, seen map[string]location, found map[string]bool) {
+
+	var fi *os.File
+	var err error
+
+	if marcfile == "-" {
+		fi = os.Stdin
+	} else {
+		fi, err = os.Open(marcfile)
+		if err != nil {
+			log.Fatal(fmt.Printf("File open failed: %q", err))
+		}
+		defer func() {
+			if cerr := fi.Close(); cerr != nil && err == nil {
+				err = cerr
+			}
+		}()
+	}
+
+	rr := details.NewRecordReader(fi)
+
+	var ordinal int
 	for {
-		rec, err := marc21.ParseNextRecord(fi)
+		rec, err := rr.Next()
 		if err == io.EOF {
 			break
 		}
 		if err != nil {
 			log.Fatal(err)
 		}
-		if rec.GetControlfield("001") == cn {
+		ordinal++
+
+		if !sel.selects(*rec, ordinal, found) {
+			continue
+		}
+
+		id := recordID(*rec)
+		loc := location{file: marcfile, ordinal: ordinal}
+		if first, ok := seen[id]; ok {
+			fmt.Fprintf(os.Stderr, "Duplicate: %s at %s record %d (first seen at %s record %d)\n",
+				id, loc.file, loc.ordinal, first.file, first.ordinal)
+		} else {
+			seen[id] = loc
+		}
+
+		if format == "json" {
+			dumpJSON(*rec)
+			continue
+		}
 
-			//fmt.Println(rec)
-			ldr := details.ParseLeader(*rec)
-			dumpLeader(ldr)
+		fmt.Printf("# %s record %d\n", marcfile, ordinal)
+		dumpText(*rec)
+		fmt.Println()
+	}
+}
+
+// selects determines if a record is selected. Control numbers that
+// are matched are flagged in found
+func (sel selector) selects(rec marc21.Record, ordinal int, found map[string]bool) bool {
+
+	ok := sel.all
+
+	cn := rec.GetControlfield("001")
+	for _, k := range []string{cn, recordID(rec)} {
+		if sel.cns[k] {
+			found[k] = true
+			ok = true
+		}
+	}
 
-			cfs := rec.GetControlfields("001,003,004,005")
-			for _, v := range cfs {
-				fmt.Printf("%s:    %s\n", v.Tag, v.Text)
+	for _, r := range sel.ranges {
+		if ordinal >= r.first && (r.last == 0 || ordinal <= r.last) {
+			ok = true
+		}
+	}
+
+	if sel.re != nil {
+		for _, cf := range rec.Controlfields {
+			if (sel.tags == nil || sel.tags[cf.Tag]) && sel.re.MatchString(cf.Text) {
+				ok = true
 			}
+		}
+	}
 
-			p6 := details.Parse006(*rec)
-			dumpCf006(p6)
+	return ok
+}
 
-			p7 := details.Parse007(*rec)
-			dumpCf007(p7)
+// recordID returns the 003 and 001 of a record as "003:001"
+func recordID(rec marc21.Record) string {
+	return rec.GetControlfield("003") + ":" + rec.GetControlfield("001")
+}
 
-			p8 := details.Parse008(*rec)
-			dumpCf008(p8)
+// readControlNumbers reads a file of control numbers, one per line.
+// Blank lines and lines starting with "#" are ignored
+func readControlNumbers(filename string, cns map[string]bool) error {
 
-			break
+	fi, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer fi.Close()
+
+	scanner := bufio.NewScanner(fi)
+	for scanner.Scan() {
+		cn := strings.TrimSpace(scanner.Text())
+		if cn == "" || strings.HasPrefix(cn, "#") {
+			continue
 		}
+		cns[cn] = true
 	}
+
+	return scanner.Err()
+}
+
+// parseRanges parses a comma separated list of record ordinals and
+// ranges of ordinals ("1-10,25,40-")
+func parseRanges(s string) (l []ordinalRange, err error) {
+
+	for _, p := range strings.Split(s, ",") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+
+		var r ordinalRange
+		n := strings.SplitN(p, "-", 2)
+
+		r.first, err = strconv.Atoi(n[0])
+		if err != nil || r.first < 1 {
+			return nil, fmt.Errorf("invalid record range %q", p)
+		}
+
+		switch {
+		case len(n) == 1:
+			r.last = r.first
+		case n[1] != "":
+			r.last, err = strconv.Atoi(n[1])
+			if err != nil || r.last < r.first {
+				return nil, fmt.Errorf("invalid record range %q", p)
+			}
+		}
+
+		l = append(l, r)
+	}
+
+	return l, nil
+}
+
+// dumpText writes the decoded record as text
+func dumpText(rec marc21.Record) {
+
+	//fmt.Println(rec)
+	ldr := details.ParseLeader(rec)
+	dumpLeader(ldr)
+
+	cfs := rec.GetControlfields("001,003,004,005")
+	for _, v := range cfs {
+		fmt.Printf("%s:    %s\n", v.Tag, v.Text)
+	}
+
+	p6 := details.Parse006(rec)
+	dumpCf006(p6)
+
+	p7 := details.Parse007(rec)
+	dumpCf007(p7)
+
+	p8 := details.Parse008(rec)
+	dumpCf008(p8)
+}
+
+// dumpJSON writes the decoded record as a single line of JSON. The
+// structure is documented in dumprec.schema.json
+func dumpJSON(rec marc21.Record) {
+
+	b, err := json.Marshal(details.DescribeRecord(rec))
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(string(b))
 }
 
 func dumpLeader(ldr details.LdrDesc) {
@@ -166,8 +420,199 @@ func dumpCV(v details.CodeValue, k string, i int) {
 
 func showHelp() {
 	fmt.Println(os.Args[0])
-	fmt.Println("   Extract the specified record from a MARC file and print the detailed results.")
-	fmt.Printf("    Usage: %s <MARC file to sesrch> <control number for the record to parse>\n", os.Args[0])
+	fmt.Println("   Extract the selected records from one or more MARC (ISO 2709 or MARCXML) files and print the detailed results.")
+	fmt.Printf("    Usage: %s [options] <MARC file to search> [control number ...]\n", os.Args[0])
+	fmt.Printf("           %s [options] -in <MARC file> [-in <MARC file> ...] [control number ...]\n", os.Args[0])
+	fmt.Println()
+	fmt.Println("    Options:")
+	fmt.Println("      -format text|json  json writes each record as one line of JSON (see dumprec.schema.json).")
+	fmt.Println("      -in <file>         A MARC file to read, - for stdin. May be repeated.")
+	fmt.Println("      -all               Dump all records.")
+	fmt.Println("      -cnfile <file>     Dump the records for the control numbers listed in the file, one per line.")
+	fmt.Println("      -range <ranges>    Dump records by their position in the file, e.g. 1-10,25,40-")
+	fmt.Println("      -match <regexp>    Dump the records having a control field that matches the regular expression.")
+	fmt.Println("      -tags <tags>       Limit -match to the listed control fields, e.g. 001,003")
+	fmt.Println()
+	fmt.Printf("    Use \"%s diff\" to compare the leader and control fields of two records (see %s diff -h).\n", os.Args[0], os.Args[0])
+	fmt.Println()
+	fmt.Println("    Control numbers are either the 001 or the 003 and 001 as 003:001 (e.g. DLC:12345).")
+	fmt.Println("    A record is dumped if it matches any of the selection criteria. Records having the same")
+	fmt.Println("    003 and 001 as an earlier record, and control numbers that are not found, are reported")
+	fmt.Println("    on stderr.")
+	fmt.Println()
+	os.Exit(0)
+}
+
+// foundRecord being a record and where it was found
+type foundRecord struct {
+	rec *marc21.Record
+	loc location
+}
+
+// diffMain compares the leader and control fields of two records
+func diffMain(args []string) {
+
+	var format, oldFile, newFile string
+
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	fs.StringVar(&format, "format", "text", "The output format (text or json).")
+	fs.StringVar(&oldFile, "old", "", "The MARC file having the old record.")
+	fs.StringVar(&newFile, "new", "", "The MARC file having the new record.")
+	fs.Usage = showDiffHelp
+	_ = fs.Parse(args)
+
+	// The files are either given by -old and -new or, for two records
+	// in the same file, as the first argument. The rest of the
+	// arguments are the control numbers.
+	var files, cns []string
+	switch {
+	case oldFile != "" || newFile != "":
+		if oldFile == "" || newFile == "" {
+			showDiffHelp()
+		}
+		files = []string{oldFile, newFile}
+		cns = fs.Args()
+	case fs.NArg() > 0:
+		files = fs.Args()[:1]
+		cns = fs.Args()[1:]
+	}
+	if len(files) == 0 || len(cns) == 0 || len(cns) > 2 || (format != "text" && format != "json") {
+		showDiffHelp()
+	}
+
+	var recs []foundRecord
+	switch {
+	case len(files) == 1 && len(cns) == 1:
+		// Two versions of a record in the same file
+		recs = findRecords(files[0], cns[0], 2)
+		if len(recs) < 2 {
+			log.Fatalf("Expected two records for %s in %s, found %d", cns[0], files[0], len(recs))
+		}
+	default:
+		for i := 0; i < 2; i++ {
+			file := files[i%len(files)]
+			cn := cns[i%len(cns)]
+			l := findRecords(file, cn, 1)
+			if len(l) == 0 {
+				log.Fatalf("Not found: %s in %s", cn, file)
+			}
+			recs = append(recs, l[0])
+		}
+	}
+
+	d := details.DiffRecords(*recs[0].rec, *recs[1].rec)
+
+	if format == "json" {
+		b, err := json.Marshal(d)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(string(b))
+		return
+	}
+
+	fmt.Printf("--- %s record %d (%s)\n", recs[0].loc.file, recs[0].loc.ordinal, recordID(*recs[0].rec))
+	fmt.Printf("+++ %s record %d (%s)\n", recs[1].loc.file, recs[1].loc.ordinal, recordID(*recs[1].rec))
+	if d.Equal() {
+		fmt.Println("No differences in the leader and control fields")
+		return
+	}
+
+	for _, fd := range d.Fields {
+		dumpFieldDiff(fd)
+	}
+}
+
+// findRecords returns up to max records of a MARC file having the
+// control number
+func findRecords(marcfile, cn string, max int) (l []foundRecord) {
+
+	fi, err := os.Open(marcfile)
+	if err != nil {
+		log.Fatal(fmt.Printf("File open failed: %q", err))
+	}
+	defer func() {
+		if cerr := fi.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	rr := details.NewRecordReader(fi)
+
+	var ordinal int
+	for len(l) < max {
+		rec, err := rr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		ordinal++
+
+		if rec.GetControlfield("001") == cn || recordID(*rec) == cn {
+			l = append(l, foundRecord{rec: rec, loc: location{file: marcfile, ordinal: ordinal}})
+		}
+	}
+
+	return l
+}
+
+// dumpFieldDiff writes the differences for a field as text
+func dumpFieldDiff(fd details.FieldDiff) {
+
+	tag := fd.Tag
+	if fd.Occurrence > 1 {
+		tag += "[" + strconv.Itoa(fd.Occurrence) + "]"
+	}
+
+	switch fd.Change {
+	case details.ChangeAdded:
+		fmt.Printf("%s added:   %s%s\n", tag, strings.Replace(fd.NewText, " ", "#", -1), materialNote(fd.NewMaterial))
+	case details.ChangeRemoved:
+		fmt.Printf("%s removed: %s%s\n", tag, strings.Replace(fd.OldText, " ", "#", -1), materialNote(fd.OldMaterial))
+	default:
+		fmt.Printf("%s changed:\n", tag)
+		fmt.Printf("    - %s%s\n", strings.Replace(fd.OldText, " ", "#", -1), materialNote(fd.OldMaterial))
+		fmt.Printf("    + %s%s\n", strings.Replace(fd.NewText, " ", "#", -1), materialNote(fd.NewMaterial))
+	}
+
+	for _, c := range fd.Elements {
+		switch {
+		case fd.Change == details.ChangeAdded:
+			fmt.Printf("    %-5s %s: %s\n", c.Positions, c.Name, details.CodeText(c.NewCode, c.NewLabel))
+		case fd.Change == details.ChangeRemoved:
+			fmt.Printf("    %-5s %s: %s\n", c.Positions, c.Name, details.CodeText(c.OldCode, c.OldLabel))
+		default:
+			fmt.Printf("    %-5s %s\n", c.Positions, c)
+		}
+	}
+}
+
+func materialNote(material string) string {
+	if material == "" {
+		return ""
+	}
+	return " (" + material + ")"
+}
+
+func showDiffHelp() {
+	fmt.Printf("%s diff\n", os.Args[0])
+	fmt.Println("   Compares the leader and control fields of two records by decoded element.")
+	fmt.Printf("    Usage: %s diff [-format text|json] <MARC file> <control number>\n", os.Args[0])
+	fmt.Printf("           %s diff [-format text|json] <MARC file> <control number> <control number>\n", os.Args[0])
+	fmt.Printf("           %s diff [-format text|json] -old <MARC file> -new <MARC file> <control number> [<control number>]\n", os.Args[0])
+	fmt.Println()
+	fmt.Println("    With one file and one control number the first two records having the control number are")
+	fmt.Println("    compared, for example the original and overlaid versions of a record. With -old and -new the")
+	fmt.Println("    record in the old file is compared to the record in the new one. Each change is reported as")
+	fmt.Println("    the element, its old code and label and its new code and label, for example:")
+	fmt.Println()
+	fmt.Println("      LDR changed:")
+	fmt.Println("          17    Encoding level: 7 Minimal level -> blank Full level")
+	fmt.Println()
+	fmt.Println("    006 and 007 fields that were added or removed are reported with their elements. json writes")
+	fmt.Println("    the differences as one line of JSON (see details.RecordDiff).")
 	fmt.Println()
 	os.Exit(0)
 }
@@ -181,6 +181,8 @@ func bannerLine() {
 func makeBibliography006FormOfMaterialList(format string, cftag *codegen.CfTag) {
 
 	varname := strings.ToLower(format) + cftag.Tag + "FormOfMaterial"
+	var obsolete []*codegen.LookupValue
+
 	fmt.Printf("var %s = map[string]string{\n", varname)
 
 	vst := validSubtags(cftag.Subtags)
@@ -190,7 +192,9 @@ func makeBibliography006FormOfMaterialList(format string, cftag *codegen.CfTag)
 		for _, cfe := range ve {
 			if cftag.Tag == "006" && cfe.CamelName == "FormOfMaterial" {
 				for _, lv := range cfe.LookupValues {
-					if !strings.Contains(lv.Label, "OBSOLETE") {
+					if strings.Contains(lv.Label, "OBSOLETE") {
+						obsolete = append(obsolete, lv)
+					} else {
 						fmt.Printf("\t%q: %q,\n", lv.Code, lv.Label)
 					}
 				}
@@ -199,11 +203,24 @@ func makeBibliography006FormOfMaterialList(format string, cftag *codegen.CfTag)
 	}
 
 	fmt.Println("}")
+
+	if len(obsolete) == 0 {
+		return
+	}
+
+	fmt.Println()
+	fmt.Printf("var %s = map[string]string{\n", obsoleteName(varname))
+	for _, lv := range obsolete {
+		fmt.Printf("\t%q: %q,\n", lv.Code, lv.Label)
+	}
+	fmt.Println("}")
 }
 
 func make007CategoryOfMaterialList(format string, cftag *codegen.CfTag) {
 
 	varname := strings.ToLower(format) + cftag.Tag + "CategoryOfMaterial"
+	var obsolete []*codegen.LookupValue
+
 	fmt.Printf("var %s = map[string]string{\n", varname)
 
 	vst := validSubtags(cftag.Subtags)
@@ -213,7 +230,9 @@ func make007CategoryOfMaterialList(format string, cftag *codegen.CfTag) {
 		for _, cfe := range ve {
 			if cftag.Tag == "007" && cfe.CamelName == "CategoryOfMaterial" {
 				for _, lv := range cfe.LookupValues {
-					if !strings.Contains(lv.Label, "OBSOLETE") {
+					if strings.Contains(lv.Label, "OBSOLETE") {
+						obsolete = append(obsolete, lv)
+					} else {
 						fmt.Printf("\t%q: %q,\n", lv.Code, lv.Label)
 					}
 				}
@@ -222,27 +241,69 @@ func make007CategoryOfMaterialList(format string, cftag *codegen.CfTag) {
 	}
 
 	fmt.Println("}")
+
+	if len(obsolete) == 0 {
+		return
+	}
+
+	fmt.Println()
+	fmt.Printf("var %s = map[string]string{\n", obsoleteName(varname))
+	for _, lv := range obsolete {
+		fmt.Printf("\t%q: %q,\n", lv.Code, lv.Label)
+	}
+	fmt.Println("}")
 }
 
+// makeLookupList writes the map of the codes for an element and, if
+// the element has any obsolete codes, a second map of those (see
+// obsoleteName)
 func makeLookupList(cfe *codegen.CfElement, varname string) {
 	fmt.Printf("var %s = map[string]string{\n", varname)
+	for _, lv := range cfe.LookupValues {
+		if !strings.Contains(lv.Label, "OBSOLETE") {
+			fmt.Printf("\t%q: %q,\n", lookupCode(lv.Code), lv.Label)
+		}
+	}
+	fmt.Println("}")
 
+	if !hasObsolete(cfe.LookupValues) {
+		return
+	}
+
+	fmt.Println()
+	fmt.Printf("var %s = map[string]string{\n", obsoleteName(varname))
 	for _, lv := range cfe.LookupValues {
 		if strings.Contains(lv.Label, "OBSOLETE") {
-			continue
+			fmt.Printf("\t%q: %q,\n", lookupCode(lv.Code), lv.Label)
 		}
+	}
+	fmt.Println("}")
+}
 
-		if lv.Code == "#" {
-			fmt.Printf("\t%q: %q,\n", " ", lv.Label)
-		} else if lv.Code == "##" {
-			fmt.Printf("\t%q: %q,\n", "  ", lv.Label)
-		} else if lv.Code == "###" {
-			fmt.Printf("\t%q: %q,\n", "   ", lv.Label)
-		} else {
-			fmt.Printf("\t%q: %q,\n", lv.Code, lv.Label)
+// lookupCode returns the code of a lookup value with the blanks ("#")
+// replaced by spaces
+func lookupCode(code string) string {
+	switch code {
+	case "#", "##", "###":
+		return strings.Repeat(" ", len(code))
+	}
+	return code
+}
+
+// hasObsolete determines if any of the lookup values are obsolete
+func hasObsolete(l []*codegen.LookupValue) bool {
+	for _, lv := range l {
+		if strings.Contains(lv.Label, "OBSOLETE") {
+			return true
 		}
 	}
-	fmt.Println("}")
+	return false
+}
+
+// obsoleteName returns the name of the map of the obsolete codes for
+// the map of codes having the specified name
+func obsoleteName(varname string) string {
+	return varname + "Obsolete"
 }
 
 func make007Funcs(format, cftag string, cfsubtag *codegen.CfSubtag) {
@@ -296,6 +357,8 @@ func make007Funcs(format, cftag string, cfsubtag *codegen.CfSubtag) {
 	fmt.Println("\treturn pd")
 	fmt.Println("}")
 	fmt.Println()
+	makeElementList(funcName, format, cftag, stcode, ve)
+	fmt.Println()
 }
 
 func make007LookupFunc(e *codegen.CfElement, fieldName, varname string) {
@@ -417,6 +480,8 @@ func make008Funcs(format, cftag string, cfsubtag *codegen.CfSubtag) {
 
 	fmt.Println("}")
 	fmt.Println()
+	makeElementList(funcName, format, cftag, stcode, ve)
+	fmt.Println()
 }
 
 func make008LookupFunc(e *codegen.CfElement, fieldName, varname string, offsetAdj int) {
@@ -487,3 +552,86 @@ func make008HybridDateFunc(e *codegen.CfElement, fieldName, varname string, offs
 		fmt.Println()
 	}
 }
+
+// makeElementList writes the list of definitions for the elements that
+// are parsed by the named parse function. Unlike the parse functions,
+// the offsets for the bibliography material specific elements are not
+// adjusted (they are the 008 offsets).
+func makeElementList(funcName, format, cftag, stcode string, ve []*codegen.CfElement) {
+
+	listName := strings.ToLower(format) + cftag + stcode + "Elements"
+
+	var defs []string
+	for _, e := range ve {
+		var varname string
+		if cftag == "007" && e.CamelName == "CategoryOfMaterial" {
+			varname = strings.ToLower(format) + cftag + e.CamelName
+		} else {
+			varname = strings.ToLower(format) + cftag + stcode + e.CamelName
+		}
+
+		if varname == "holdings008SpecificRetentionPolicy" {
+			continue
+		}
+
+		d := makeElementDef(e, varname)
+		if d != "" {
+			defs = append(defs, d)
+		}
+	}
+
+	fmt.Printf("// %s defines the elements that are parsed by\n", listName)
+	fmt.Printf("// %s\n", funcName)
+	if len(defs) == 0 {
+		fmt.Printf("var %s = []ElementDef{}\n", listName)
+		return
+	}
+
+	fmt.Printf("var %s = []ElementDef{\n", listName)
+	for _, d := range defs {
+		fmt.Printf("\t%s\n", d)
+	}
+	fmt.Println("}")
+}
+
+// makeElementDef returns the definition for a single element. Elements
+// that do not get parsed result in an empty string.
+func makeElementDef(e *codegen.CfElement, varname string) string {
+
+	codeWidth := e.CodeWidth
+
+	switch e.FnType {
+	case "read", "range":
+		return fmt.Sprintf("{Name: %q, Offset: %d, Width: %d, CodeWidth: %d, FnType: %q},",
+			e.Name, e.Offset, e.Width, e.Width, "read")
+	case "lookup":
+		codeWidth = e.Width
+	case "hybrid-date":
+		codeWidth = 1
+	case "multi", "hybrid":
+	default:
+		return ""
+	}
+
+	if len(e.LookupValues) == 0 {
+		return ""
+	}
+
+	d := fmt.Sprintf("{Name: %q, Offset: %d, Width: %d, CodeWidth: %d, FnType: %q, Codes: %s",
+		e.Name, e.Offset, e.Width, codeWidth, e.FnType, varname)
+
+	if hasObsolete(e.LookupValues) {
+		d += fmt.Sprintf(", Obsolete: %s", obsoleteName(varname))
+	}
+
+	if e.FnType == "hybrid" {
+		for _, lv := range e.LookupValues {
+			if strings.Contains(lv.Code, "-") || strings.Contains(lv.Code, "[") {
+				d += fmt.Sprintf(", RangeLabel: %q", lv.Label)
+				break
+			}
+		}
+	}
+
+	return d + "},"
+}
@@ -71,25 +71,56 @@ func bannerLine() {
 	fmt.Println("////////////////////////////////////////////////////////////////////////")
 }
 
+// makeLookupList writes the map of the codes for an element and, if
+// the element has any obsolete codes, a second map of those (see
+// obsoleteName)
 func makeLookupList(ldre *codegen.LdrElement, varname string) {
 	fmt.Printf("var %s = map[string]string{\n", varname)
+	for _, lv := range ldre.LookupValues {
+		if !strings.Contains(lv.Label, "OBSOLETE") {
+			fmt.Printf("\t%q: %q,\n", lookupCode(lv.Code), lv.Label)
+		}
+	}
+	fmt.Println("}")
+
+	if !hasObsolete(ldre.LookupValues) {
+		return
+	}
 
+	fmt.Println()
+	fmt.Printf("var %s = map[string]string{\n", obsoleteName(varname))
 	for _, lv := range ldre.LookupValues {
 		if strings.Contains(lv.Label, "OBSOLETE") {
-			continue
+			fmt.Printf("\t%q: %q,\n", lookupCode(lv.Code), lv.Label)
 		}
+	}
+	fmt.Println("}")
+}
 
-		if lv.Code == "#" {
-			fmt.Printf("\t%q: %q,\n", " ", lv.Label)
-		} else if lv.Code == "##" {
-			fmt.Printf("\t%q: %q,\n", "  ", lv.Label)
-		} else if lv.Code == "###" {
-			fmt.Printf("\t%q: %q,\n", "   ", lv.Label)
-		} else {
-			fmt.Printf("\t%q: %q,\n", lv.Code, lv.Label)
+// lookupCode returns the code of a lookup value with the blanks ("#")
+// replaced by spaces
+func lookupCode(code string) string {
+	switch code {
+	case "#", "##", "###":
+		return strings.Repeat(" ", len(code))
+	}
+	return code
+}
+
+// hasObsolete determines if any of the lookup values are obsolete
+func hasObsolete(l []*codegen.LookupValue) bool {
+	for _, lv := range l {
+		if strings.Contains(lv.Label, "OBSOLETE") {
+			return true
 		}
 	}
-	fmt.Println("}")
+	return false
+}
+
+// obsoleteName returns the name of the map of the obsolete codes for
+// the map of codes having the specified name
+func obsoleteName(varname string) string {
+	return varname + "Obsolete"
 }
 
 func makeFuncs(format string, ldr codegen.Ldr) {
@@ -129,6 +160,8 @@ func makeFuncs(format string, ldr codegen.Ldr) {
 	fmt.Println("\treturn ldr")
 	fmt.Println("}")
 	fmt.Println()
+	makeElementList(funcName, format, ldr)
+	fmt.Println()
 }
 
 func makeLdrLookupFunc(e *codegen.LdrElement, fieldName, varname string) {
@@ -143,3 +176,40 @@ func makeLdrReadFunc(e *codegen.LdrElement, fieldName, varname string) {
 	fmt.Printf("\tldr[%q] = CodeValue{Code: pluckBytes(s, %d, %d), Label: \"\", Offset: %d, Width: %d}\n",
 		fieldName, e.Offset, e.Width, e.Offset, e.Width)
 }
+
+// makeElementList writes the list of definitions for the elements that
+// are parsed by the leader parse function
+func makeElementList(funcName, format string, ldr codegen.Ldr) {
+
+	listName := strings.ToLower(format) + "LdrElements"
+
+	fmt.Printf("// %s defines the elements that are parsed by\n", listName)
+	fmt.Printf("// %s\n", funcName)
+	fmt.Printf("var %s = []ElementDef{\n", listName)
+
+	ve := validElements(ldr.Elements)
+	for _, e := range ve {
+		varname := strings.ToLower(format) + "Ldr" + e.CamelName
+
+		if e.CamelName == "EntryMap" {
+			continue
+		}
+
+		switch e.FnType {
+		case "lookup":
+			if len(e.LookupValues) > 0 {
+				d := fmt.Sprintf("{Name: %q, Offset: %d, Width: %d, CodeWidth: %d, FnType: %q, Codes: %s",
+					e.Name, e.Offset, e.Width, e.Width, e.FnType, varname)
+				if hasObsolete(e.LookupValues) {
+					d += fmt.Sprintf(", Obsolete: %s", obsoleteName(varname))
+				}
+				fmt.Printf("\t%s},\n", d)
+			}
+		case "read":
+			fmt.Printf("\t{Name: %q, Offset: %d, Width: %d, CodeWidth: %d, FnType: %q},\n",
+				e.Name, e.Offset, e.Width, e.Width, e.FnType)
+		}
+	}
+
+	fmt.Println("}")
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	//
+	"github.com/gsiems/go-marc21-details/pkg/details"
+)
+
+func main() {
+
+	var marcfile string
+	if len(os.Args) > 1 {
+		marcfile = os.Args[1]
+	}
+
+	if marcfile == "" {
+		showHelp()
+	}
+
+	fi, err := os.Open(marcfile)
+	if err != nil {
+		log.Fatal(fmt.Printf("File open failed: %q", err))
+	}
+	defer func() {
+		if cerr := fi.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	fmt.Print(details.AnnotatedCollectionXMLHeader)
+
+	rr := details.NewRecordReader(fi)
+
+	for {
+		rec, err := rr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		recxml, err := details.AnnotatedXML(*rec)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Print(recxml)
+	}
+
+	fmt.Print(details.AnnotatedCollectionXMLFooter)
+}
+
+func showHelp() {
+	fmt.Println(os.Args[0])
+	fmt.Println("   Converts a MARC (ISO 2709 or MARCXML) file to MARCXML with the decoded leader and control field")
+	fmt.Println("   information and the data field names added (see pkg/details/marcxml.go for the layout).")
+	fmt.Printf("    Usage: %s <MARC file to convert>\n", os.Args[0])
+	fmt.Println()
+	os.Exit(0)
+}
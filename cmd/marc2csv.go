@@ -0,0 +1,247 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	//
+	"github.com/gsiems/go-marc21-details/pkg/details"
+	"github.com/gsiems/go-marc21/pkg/marc21"
+)
+
+// column being a column of the output and the information needed to
+// sort the columns into field and position order
+type column struct {
+	name     string
+	rank     int
+	occ      int
+	group    int
+	material string
+	offset   int
+	seq      int
+}
+
+func main() {
+
+	var format, cell string
+	var max007 int
+
+	flag.StringVar(&format, "format", "csv", "The output format (csv or tsv).")
+	flag.StringVar(&cell, "cell", "code", "What to write for each element (code, label or both).")
+	flag.IntVar(&max007, "n", 1, "The number of 007 fields to write per record.")
+	flag.Usage = showHelp
+	flag.Parse()
+
+	marcfile := flag.Arg(0)
+
+	if marcfile == "" || (format != "csv" && format != "tsv") || (cell != "code" && cell != "label" && cell != "both") || max007 < 0 {
+		showHelp()
+	}
+
+	fi, err := os.Open(marcfile)
+	if err != nil {
+		log.Fatal(fmt.Printf("File open failed: %q", err))
+	}
+	defer func() {
+		if cerr := fi.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	// First pass: determine the set of columns
+	cols := make(map[string]column)
+	err = eachRecord(fi, func(rec marc21.Record) {
+		for _, c := range recordColumns(details.DescribeRecord(rec), max007) {
+			cols[c.name] = c.column
+		}
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var header []column
+	for _, c := range cols {
+		header = append(header, c)
+	}
+	sort.Slice(header, func(i, j int) bool { return header[i].less(header[j]) })
+
+	w := csv.NewWriter(os.Stdout)
+	if format == "tsv" {
+		w.Comma = '\t'
+	}
+
+	row := []string{"001", "format", "material"}
+	for _, c := range header {
+		row = append(row, c.name)
+	}
+	writeRow(w, row)
+
+	// Second pass: write the records
+	_, err = fi.Seek(0, io.SeekStart)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = eachRecord(fi, func(rec marc21.Record) {
+
+		rd := details.DescribeRecord(rec)
+
+		values := make(map[string]string)
+		for _, c := range recordColumns(rd, max007) {
+			values[c.name] = cellValue(c.element, cell)
+		}
+
+		row := []string{rd.ControlNumber, rd.Format, rd.Material}
+		for _, c := range header {
+			row = append(row, values[c.name])
+		}
+		writeRow(w, row)
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// eachRecord calls fn for each record in the file
+func eachRecord(fi io.Reader, fn func(rec marc21.Record)) error {
+	rr := details.NewRecordReader(fi)
+	for {
+		rec, err := rr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		fn(*rec)
+	}
+}
+
+// recordElement being an element of a record and the column that it
+// is written to
+type recordElement struct {
+	column
+	element details.ElementDesc
+}
+
+// recordColumns returns the columns for the leader, 008 and first
+// max007 007 elements of a decoded record. Columns are named using the
+// element keys, with 007 keys being prefixed with the occurrence
+// ("007_1.", "007_2.", etc.) and the values of multi-code elements
+// being suffixed with the sequence (".1", ".2", etc.)
+func recordColumns(rd details.RecordDesc, max007 int) (l []recordElement) {
+
+	var n007 int
+	for _, fd := range rd.Fields {
+
+		var rank, occ int
+		switch fd.Tag {
+		case "LDR":
+			rank = 0
+		case "008":
+			rank = 1
+		case "007":
+			n007++
+			if n007 > max007 {
+				continue
+			}
+			rank = 2
+			occ = n007
+		default:
+			continue
+		}
+
+		for _, e := range fd.Elements {
+
+			c := column{name: e.Key, rank: rank, occ: occ, offset: e.Offset, seq: e.Seq}
+
+			if occ > 0 {
+				c.name = "007_" + strconv.Itoa(occ) + strings.TrimPrefix(c.name, "007")
+			}
+			if e.Seq > 0 {
+				c.name += "." + strconv.Itoa(e.Seq)
+			}
+
+			// Material specific elements go between the common
+			// elements that precede and follow them
+			if p := strings.Split(e.Key, "."); len(p) > 2 {
+				c.group = 1
+				c.material = p[1]
+			} else if rank > 0 && e.Offset > 0 {
+				c.group = 2
+				if fd.Tag == "008" && e.Offset < 18 {
+					c.group = 0
+				}
+			}
+
+			l = append(l, recordElement{column: c, element: e})
+		}
+	}
+
+	return l
+}
+
+// less orders columns by field, occurrence and position
+func (c column) less(o column) bool {
+	switch {
+	case c.rank != o.rank:
+		return c.rank < o.rank
+	case c.occ != o.occ:
+		return c.occ < o.occ
+	case c.group != o.group:
+		return c.group < o.group
+	case c.material != o.material:
+		return c.material < o.material
+	case c.offset != o.offset:
+		return c.offset < o.offset
+	}
+	return c.seq < o.seq
+}
+
+// cellValue returns the code, label or both for an element. Blanks in
+// codes are written as "#"
+func cellValue(e details.ElementDesc, cell string) string {
+
+	code := strings.Replace(e.Code, " ", "#", -1)
+
+	switch cell {
+	case "label":
+		return e.Label
+	case "both":
+		if e.Label != "" {
+			return code + ": " + e.Label
+		}
+	}
+
+	return code
+}
+
+func writeRow(w *csv.Writer, row []string) {
+	if err := w.Write(row); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func showHelp() {
+	fmt.Println(os.Args[0])
+	fmt.Println("   Writes the decoded leader, 008 and 007 elements of the records in a MARC (ISO 2709 or MARCXML) file as CSV (or TSV), one row per record.")
+	fmt.Printf("    Usage: %s [-format csv|tsv] [-cell code|label|both] [-n <number of 007s>] <MARC file to export>\n", os.Args[0])
+	fmt.Println()
+	fmt.Println("    Columns are named using the element keys (ldr.encoding_level, 008.bk.target_audience, etc.).")
+	fmt.Println("    007 columns are prefixed with the occurrence (007_1., 007_2., etc.) and the codes of")
+	fmt.Println("    multi-code elements are suffixed with the sequence (.1, .2, etc.). Blanks in codes are")
+	fmt.Println("    written as #.")
+	fmt.Println()
+	os.Exit(0)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	//
+	"github.com/gsiems/go-marc21-details/pkg/details"
+)
+
+func main() {
+
+	var marcfile string
+	if len(os.Args) > 1 {
+		marcfile = os.Args[1]
+	}
+
+	if marcfile == "" {
+		showHelp()
+	}
+
+	// Optional list of the control numbers of the records to report on
+	cns := make(map[string]bool)
+	for _, cn := range os.Args[2:] {
+		cns[cn] = true
+	}
+
+	fi, err := os.Open(marcfile)
+	if err != nil {
+		log.Fatal(fmt.Printf("File open failed: %q", err))
+	}
+	defer func() {
+		if cerr := fi.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	fmt.Print(details.HTMLReportHeader)
+
+	rr := details.NewRecordReader(fi)
+
+	for {
+		rec, err := rr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if len(cns) > 0 && !cns[rec.GetControlfield("001")] {
+			continue
+		}
+
+		rechtml, err := details.RecordAsHTML(*rec)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Print(rechtml)
+	}
+
+	fmt.Print(details.HTMLReportFooter)
+}
+
+func showHelp() {
+	fmt.Println(os.Args[0])
+	fmt.Println("   Writes a self-contained HTML report for the records in a MARC (ISO 2709 or MARCXML) file.")
+	fmt.Printf("    Usage: %s <MARC file to report on> [control number ...]\n", os.Args[0])
+	fmt.Println()
+	fmt.Println("    If control numbers are given then only those records are reported on.")
+	fmt.Println()
+	os.Exit(0)
+}
@@ -0,0 +1,612 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"unicode/utf8"
+	//
+	"github.com/gsiems/go-marc21-details/pkg/details"
+	"github.com/gsiems/go-marc21/pkg/marc21"
+)
+
+const (
+	modeList = iota
+	modeRecord
+)
+
+// ANSI escape sequences
+const (
+	escClear   = "\x1b[H\x1b[2J"
+	escReverse = "\x1b[7m"
+	escBold    = "\x1b[1m"
+	escRed     = "\x1b[31m"
+	escReset   = "\x1b[0m"
+)
+
+// entry is a record of the file
+type entry struct {
+	offset int64
+	cn     string
+	title  string
+}
+
+// item is a selectable element of the record view
+type item struct {
+	field   int
+	element int
+}
+
+type browser struct {
+	fi      *os.File
+	name    string
+	entries []entry
+	mode    int
+	cur     int
+	top     int
+	rec     *marc21.Record
+	rd      details.RecordDesc
+	items   []item
+	sel     int
+	rows    int
+	cols    int
+	search  string
+	message string
+}
+
+func main() {
+
+	flag.Usage = showHelp
+	flag.Parse()
+
+	marcfile := flag.Arg(0)
+	if marcfile == "" || flag.NArg() > 1 {
+		showHelp()
+	}
+
+	fi, err := os.Open(marcfile)
+	if err != nil {
+		log.Fatal(fmt.Printf("File open failed: %q", err))
+	}
+	defer func() {
+		if cerr := fi.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	b := &browser{fi: fi, name: marcfile}
+	b.entries, err = indexFile(fi)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(b.entries) == 0 {
+		log.Fatalf("No records found in %s", marcfile)
+	}
+
+	restore, err := setupTerminal()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer restore()
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sig
+		restore()
+		os.Exit(1)
+	}()
+
+	b.run()
+}
+
+// indexFile returns the offset, control number and title of each
+// record of the file
+func indexFile(fi *os.File) (l []entry, err error) {
+
+	r := bufio.NewReader(fi)
+
+	var offset int64
+	for {
+		rawRec, err := details.ReadRawRecord(r)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return l, err
+		}
+
+		e := entry{offset: offset}
+		offset += int64(len(rawRec))
+
+		rec, err := marc21.ParseRecord(rawRec)
+		if err != nil {
+			e.title = "[" + err.Error() + "]"
+		} else {
+			e.cn = rec.GetControlfield("001")
+			e.title = recordTitle(*rec)
+		}
+		l = append(l, e)
+	}
+
+	return l, nil
+}
+
+// recordTitle returns the title proper (245 $a $b) of a record
+func recordTitle(rec marc21.Record) string {
+
+	var t []string
+	for _, df := range rec.GetDatafields("245") {
+		for _, sf := range df.GetSubfields("ab") {
+			t = append(t, sf.GetText())
+		}
+	}
+
+	return strings.TrimRight(strings.Join(t, " "), " /:;,.")
+}
+
+// setupTerminal puts the terminal into non-canonical mode without echo
+// and switches to the alternate screen. The returned function restores
+// the terminal.
+func setupTerminal() (func(), error) {
+
+	state, err := stty("-g")
+	if err != nil {
+		return nil, fmt.Errorf("a terminal is required: %s", err)
+	}
+	_, err = stty("-icanon", "-echo", "min", "1")
+	if err != nil {
+		return nil, err
+	}
+	fmt.Print("\x1b[?1049h\x1b[?25l")
+
+	restored := false
+	return func() {
+		if restored {
+			return
+		}
+		restored = true
+		fmt.Print("\x1b[?25h\x1b[?1049l")
+		_, _ = stty(strings.TrimSpace(state))
+	}, nil
+}
+
+func stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	out, err := cmd.Output()
+	return string(out), err
+}
+
+// terminalSize returns the number of rows and columns of the terminal
+func terminalSize() (rows, cols int) {
+
+	rows, cols = 24, 80
+
+	out, err := stty("size")
+	if err != nil {
+		return rows, cols
+	}
+	p := strings.Fields(out)
+	if len(p) == 2 {
+		if r, err := strconv.Atoi(p[0]); err == nil && r > 5 {
+			rows = r
+		}
+		if c, err := strconv.Atoi(p[1]); err == nil && c > 20 {
+			cols = c
+		}
+	}
+
+	return rows, cols
+}
+
+// pending contains the input that has been read but not yet returned
+// as keys
+var pending []byte
+
+// readKey reads a key press, returning escape sequences (arrow keys,
+// etc.) as a single key
+func readKey() string {
+
+	if len(pending) == 0 {
+		var buf [64]byte
+		n, err := os.Stdin.Read(buf[:])
+		if err != nil || n == 0 {
+			return "Q"
+		}
+		pending = append(pending, buf[:n]...)
+	}
+
+	n := 1
+	switch {
+	case pending[0] == 0x1b && len(pending) > 2 && pending[1] == '[':
+		// A CSI sequence ends with a byte in the range @ to ~
+		for n = 2; n < len(pending) && (pending[n] < '@' || pending[n] > '~'); n++ {
+		}
+		if n < len(pending) {
+			n++
+		}
+	case pending[0] >= 0x80:
+		_, n = utf8.DecodeRune(pending)
+	}
+
+	k := string(pending[:n])
+	pending = pending[n:]
+
+	return k
+}
+
+// prompt reads a line of input on the bottom line of the screen
+func (b *browser) prompt(label string) (string, bool) {
+
+	var s []rune
+	for {
+		fmt.Printf("\x1b[%d;1H\x1b[2K%s%s", b.rows, label, string(s))
+		k := readKey()
+		switch k {
+		case "\r", "\n":
+			return string(s), true
+		case "\x1b":
+			return "", false
+		case "\x7f", "\b":
+			if len(s) > 0 {
+				s = s[:len(s)-1]
+			}
+		default:
+			if !strings.HasPrefix(k, "\x1b") {
+				s = append(s, []rune(k)...)
+			}
+		}
+	}
+}
+
+func (b *browser) run() {
+
+	for {
+		b.rows, b.cols = terminalSize()
+		if b.mode == modeList {
+			b.drawList()
+		} else {
+			b.drawRecord()
+		}
+		b.message = ""
+
+		k := readKey()
+		switch k {
+		case "q", "Q":
+			if b.mode == modeList || k == "Q" {
+				return
+			}
+			b.mode = modeList
+		case "\x1b":
+			b.mode = modeList
+		case "/":
+			b.find()
+		case "#", "c":
+			b.jump()
+		default:
+			if b.mode == modeList {
+				b.listKey(k)
+			} else {
+				b.recordKey(k)
+			}
+		}
+	}
+}
+
+// listKey handles the keys of the record list
+func (b *browser) listKey(k string) {
+
+	page := b.rows - 3
+
+	switch k {
+	case "j", "\x1b[B":
+		b.cur++
+	case "k", "\x1b[A":
+		b.cur--
+	case " ", "\x1b[6~":
+		b.cur += page
+	case "b", "\x1b[5~":
+		b.cur -= page
+	case "g", "\x1b[H":
+		b.cur = 0
+	case "G", "\x1b[F":
+		b.cur = len(b.entries) - 1
+	case "\r", "\n", "\x1b[C":
+		b.open(b.cur)
+	}
+	b.clamp()
+}
+
+// recordKey handles the keys of the record view
+func (b *browser) recordKey(k string) {
+
+	switch k {
+	case "j", "\x1b[B":
+		if b.sel < len(b.items)-1 {
+			b.sel++
+		}
+	case "k", "\x1b[A":
+		if b.sel > 0 {
+			b.sel--
+		}
+	case "\t":
+		// The first element of the next field
+		for i := b.sel + 1; i < len(b.items); i++ {
+			if b.items[i].field != b.items[b.sel].field {
+				b.sel = i
+				break
+			}
+		}
+	case "n", "\x1b[C", " ", "\x1b[6~":
+		if b.cur < len(b.entries)-1 {
+			b.open(b.cur + 1)
+		} else {
+			b.message = "Last record"
+		}
+	case "p", "\x1b[D", "b", "\x1b[5~":
+		if b.cur > 0 {
+			b.open(b.cur - 1)
+		} else {
+			b.message = "First record"
+		}
+	}
+}
+
+func (b *browser) clamp() {
+
+	if b.cur >= len(b.entries) {
+		b.cur = len(b.entries) - 1
+	}
+	if b.cur < 0 {
+		b.cur = 0
+	}
+
+	page := b.rows - 3
+	if b.cur < b.top {
+		b.top = b.cur
+	}
+	if b.cur >= b.top+page {
+		b.top = b.cur - page + 1
+	}
+}
+
+// open reads and decodes a record
+func (b *browser) open(i int) {
+
+	b.cur = i
+	b.clamp()
+
+	_, err := b.fi.Seek(b.entries[i].offset, io.SeekStart)
+	if err == nil {
+		var rawRec []byte
+		rawRec, err = details.ReadRawRecord(bufio.NewReader(b.fi))
+		if err == nil {
+			b.rec, err = marc21.ParseRecord(rawRec)
+		}
+	}
+	if err != nil {
+		b.message = fmt.Sprintf("Record %d: %s", i+1, err)
+		b.mode = modeList
+		return
+	}
+
+	b.rd = details.DescribeRecord(*b.rec)
+	b.items = nil
+	for fi, fd := range b.rd.Fields {
+		for ei := range fd.Elements {
+			b.items = append(b.items, item{fi, ei})
+		}
+	}
+
+	// Keep the selected element when stepping through the records
+	if b.mode != modeRecord || b.sel >= len(b.items) {
+		b.sel = 0
+	}
+	b.mode = modeRecord
+}
+
+// find searches forward for a record whose control number or title
+// contains the search text. An empty search repeats the last search.
+func (b *browser) find() {
+
+	s, ok := b.prompt("Search: ")
+	if !ok {
+		return
+	}
+	if s != "" {
+		b.search = s
+	}
+	if b.search == "" {
+		return
+	}
+	q := strings.ToLower(b.search)
+
+	for n := 1; n <= len(b.entries); n++ {
+		i := (b.cur + n) % len(b.entries)
+		e := b.entries[i]
+		if strings.Contains(strings.ToLower(e.cn), q) || strings.Contains(strings.ToLower(e.title), q) {
+			b.goTo(i)
+			return
+		}
+	}
+
+	b.message = fmt.Sprintf("%q not found", b.search)
+}
+
+// jump goes to the record with a control number
+func (b *browser) jump() {
+
+	s, ok := b.prompt("Control number: ")
+	if !ok || s == "" {
+		return
+	}
+
+	for i, e := range b.entries {
+		if e.cn == s {
+			b.goTo(i)
+			return
+		}
+	}
+
+	b.message = fmt.Sprintf("No record %q", s)
+}
+
+func (b *browser) goTo(i int) {
+	if b.mode == modeRecord {
+		b.open(i)
+		return
+	}
+	b.cur = i
+	b.clamp()
+}
+
+func (b *browser) drawList() {
+
+	var sb strings.Builder
+	sb.WriteString(escClear)
+
+	sb.WriteString(escBold + b.fit(fmt.Sprintf("%s: %d records", b.name, len(b.entries))) + escReset + "\n")
+
+	page := b.rows - 3
+	for i := b.top; i < len(b.entries) && i < b.top+page; i++ {
+		e := b.entries[i]
+		line := b.fit(fmt.Sprintf("%6d  %-16s %s", i+1, e.cn, e.title))
+		if i == b.cur {
+			line = escReverse + line + escReset
+		}
+		sb.WriteString(line + "\n")
+	}
+
+	b.status(&sb, "j/k move  enter open  / search  # control number  q quit")
+	fmt.Print(sb.String())
+}
+
+func (b *browser) drawRecord() {
+
+	var sb strings.Builder
+	sb.WriteString(escClear)
+
+	e := b.entries[b.cur]
+	sb.WriteString(escBold + b.fit(fmt.Sprintf("%d/%d  %s  %s", b.cur+1, len(b.entries), e.cn, e.title)) + escReset + "\n")
+	desc := b.rd.Format
+	if b.rd.Material != "" {
+		desc += " " + b.rd.Material
+	}
+	sb.WriteString(b.fit(desc) + "\n\n")
+
+	var sel item
+	var cur details.ElementDesc
+	if len(b.items) > 0 {
+		sel = b.items[b.sel]
+		cur = b.rd.Fields[sel.field].Elements[sel.element]
+	}
+
+	// The raw fields with a position ruler, highlighting the selected
+	// element
+	var tens, units strings.Builder
+	for i := 0; i < 40; i++ {
+		if i%10 == 0 {
+			tens.WriteString(strconv.Itoa(i / 10))
+		} else {
+			tens.WriteByte(' ')
+		}
+		units.WriteString(strconv.Itoa(i % 10))
+	}
+	sb.WriteString("     " + tens.String() + "\n")
+	sb.WriteString("     " + units.String() + "\n")
+
+	lines := 5
+	for i, fd := range b.rd.Fields {
+		text := strings.Replace(fd.Text, " ", "#", -1)
+		if len(b.items) > 0 && i == sel.field && cur.Offset < len(text) {
+			end := cur.Offset + cur.Width
+			if end > len(text) {
+				end = len(text)
+			}
+			text = text[:cur.Offset] + escReverse + text[cur.Offset:end] + escReset + text[end:]
+		}
+		sb.WriteString(fmt.Sprintf("%-4s %s\n", fd.Tag, text))
+		lines++
+	}
+	sb.WriteString("\n")
+	lines++
+
+	// The elements of the selected field
+	if len(b.items) > 0 {
+		fd := b.rd.Fields[sel.field]
+		name := fd.Tag
+		if fd.Material != "" {
+			name += " " + fd.Material
+		}
+		sb.WriteString(escBold + name + escReset + "\n")
+		lines++
+
+		avail := b.rows - lines - 1
+		first := 0
+		if sel.element >= avail {
+			first = sel.element - avail + 1
+		}
+		for j := first; j < len(fd.Elements) && j < first+avail; j++ {
+			ed := fd.Elements[j]
+			name := ed.Name
+			if ed.Seq > 0 {
+				name += " " + strconv.Itoa(ed.Seq)
+			}
+			line := b.fit(fmt.Sprintf("%s/%-5s %-40s %-4s %s", fd.Tag, ed.Positions, name, strings.Replace(ed.Code, " ", "#", -1), ed.Label))
+			if ed.Status == details.StatusUndefined || ed.Status == details.StatusObsolete || ed.Status == details.StatusMissing {
+				line = escRed + b.fit(line+" ["+ed.Status+"]") + escReset
+			}
+			if j == sel.element {
+				line = escReverse + line + escReset
+			}
+			sb.WriteString(line + "\n")
+		}
+	}
+
+	b.status(&sb, "j/k element  tab field  n/p record  / search  # control number  q list  Q quit")
+	fmt.Print(sb.String())
+}
+
+// status writes the message, or the key help, on the bottom line
+func (b *browser) status(sb *strings.Builder, help string) {
+	s := b.message
+	if s == "" {
+		s = help
+	}
+	sb.WriteString(fmt.Sprintf("\x1b[%d;1H%s", b.rows, escBold+b.fit(s)+escReset))
+}
+
+// fit truncates a line to the width of the terminal
+func (b *browser) fit(s string) string {
+	r := []rune(s)
+	if len(r) > b.cols {
+		return string(r[:b.cols])
+	}
+	return s
+}
+
+func showHelp() {
+	fmt.Println(os.Args[0])
+	fmt.Println("   Browses the records of a MARC file in the terminal, showing the decoded leader, 006, 007 and")
+	fmt.Println("   008 elements alongside the raw fields. The MARC file must be ISO 2709; MARCXML is not supported.")
+	fmt.Printf("    Usage: %s <MARC file>\n", os.Args[0])
+	fmt.Println()
+	fmt.Println("    Record list:  j/k or arrows move, space/b page, g/G first/last, enter opens the record")
+	fmt.Println("    Record view:  j/k or arrows select an element (highlighted in the raw field), tab goes to the")
+	fmt.Println("                  next field, n/p or left/right step through the records, q returns to the list")
+	fmt.Println("    Anywhere:     / searches the control numbers and titles (enter on an empty search repeats")
+	fmt.Println("                  it), # jumps to a control number, Q quits")
+	fmt.Println()
+	fmt.Println("    Requires a terminal that supports ANSI escape sequences and the stty command.")
+	fmt.Println()
+	os.Exit(0)
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	//
+	"github.com/gsiems/go-marc21-details/pkg/details"
+)
+
+func main() {
+
+	var formatName, tag, material, output string
+	var codes, labels bool
+
+	flag.StringVar(&formatName, "format", "", "Limit the search to a record format (bibliography, holdings, authority, classification or community).")
+	flag.StringVar(&tag, "tag", "", "Limit the search to the leader (LDR) or a control field (006, 007 or 008).")
+	flag.StringVar(&material, "material", "", "Limit the search to a material (BK, MP, etc., a 006 or 007 code, or a name).")
+	flag.BoolVar(&codes, "code", false, "Search the codes only.")
+	flag.BoolVar(&labels, "label", false, "Search the labels only.")
+	flag.StringVar(&output, "output", "text", "The output format (text or json).")
+	flag.Usage = showHelp
+	flag.Parse()
+
+	if flag.NArg() != 1 || (output != "text" && output != "json") {
+		showHelp()
+	}
+	q := flag.Arg(0)
+
+	opt := details.SearchOptions{Tag: tag, Material: material, Codes: codes, Labels: labels}
+	if formatName != "" {
+		var ok bool
+		opt.Format, ok = parseFormat(formatName)
+		if !ok {
+			showHelp()
+		}
+	}
+
+	// Short queries are codes, longer ones are labels
+	if !codes && !labels {
+		opt.Codes = len(q) <= 3 && !strings.Contains(q, " ")
+		opt.Labels = !opt.Codes
+	}
+
+	l := details.SearchCodes(q, opt)
+
+	if output == "json" {
+		b, err := json.MarshalIndent(l, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(string(b))
+		return
+	}
+
+	for _, cm := range l {
+		field := cm.Field
+		if cm.Material != "" {
+			field += " (" + cm.Material + ")"
+		}
+		fmt.Printf("%-14s %s/%-5s %-4s %s; %s; %s\n",
+			cm.Format, cm.Tag, cm.Positions, strings.Replace(cm.Code, " ", "#", -1), cm.Label, cm.Element, field)
+	}
+	if len(l) == 0 {
+		fmt.Println("No matching codes")
+	}
+}
+
+// parseFormat returns the record format for a format name
+func parseFormat(name string) (int, bool) {
+
+	for _, format := range details.Formats() {
+		s := strings.ToLower(details.FormatName(format))
+		if strings.HasPrefix(s, strings.ToLower(name)) {
+			return format, true
+		}
+	}
+
+	return 0, false
+}
+
+func showHelp() {
+	fmt.Println(os.Args[0])
+	fmt.Println("   Searches the codes and labels of the leader, 006, 007 and 008 elements of all record formats.")
+	fmt.Printf("    Usage: %s [-format <format>] [-tag <tag>] [-material <material>] [-code|-label] [-output text|json] <query>\n", os.Args[0])
+	fmt.Println()
+	fmt.Println("    Queries of up to three characters are matched against the codes (use # for blank), longer")
+	fmt.Println("    queries against the labels (case-insensitive, anywhere in the label); use -code or -label to")
+	fmt.Println("    choose. The format may be abbreviated. For example:")
+	fmt.Println()
+	fmt.Printf("      %s braille\n", os.Args[0])
+	fmt.Printf("      %s -format bib -tag 008 -material BK f\n", os.Args[0])
+	fmt.Println()
+	os.Exit(0)
+}
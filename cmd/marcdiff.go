@@ -0,0 +1,193 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	//
+	"github.com/gsiems/go-marc21-details/pkg/details"
+	"github.com/gsiems/go-marc21/pkg/marc21"
+)
+
+// keyFunc returns the key used to match a record, or "" if the record
+// has none
+type keyFunc func(rec marc21.Record) string
+
+var keyFuncs = map[string]keyFunc{
+	"cn": func(rec marc21.Record) string {
+		cn := rec.GetControlfield("001")
+		if cn == "" {
+			return ""
+		}
+		return rec.GetControlfield("003") + ":" + cn
+	},
+	"001": func(rec marc21.Record) string {
+		return rec.GetControlfield("001")
+	},
+	"035": func(rec marc21.Record) string {
+		for _, df := range rec.GetDatafields("035") {
+			for _, sf := range df.GetSubfields("a") {
+				if sf.GetText() != "" {
+					return sf.GetText()
+				}
+			}
+		}
+		return ""
+	},
+}
+
+// keyedRecords being the records of a file by key, with the keys in
+// file order
+type keyedRecords struct {
+	keys    []string
+	records map[string]*marc21.Record
+}
+
+func main() {
+
+	var key, format string
+	var list bool
+
+	flag.StringVar(&key, "key", "cn", "How records are matched (cn for 003:001, 001 or 035).")
+	flag.StringVar(&format, "format", "text", "The output format (text or json).")
+	flag.BoolVar(&list, "list", false, "List the added, deleted and changed records.")
+	flag.Usage = showHelp
+	flag.Parse()
+
+	kf, ok := keyFuncs[key]
+	if flag.NArg() != 2 || !ok || (format != "text" && format != "json") {
+		showHelp()
+	}
+
+	before := readKeyed(flag.Arg(0), kf)
+
+	r := details.NewChangeReport()
+	matched := make(map[string]bool)
+
+	fi, err := os.Open(flag.Arg(1))
+	if err != nil {
+		log.Fatal(fmt.Printf("File open failed: %q", err))
+	}
+	defer func() {
+		if cerr := fi.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	rr := details.NewRecordReader(fi)
+
+	var ordinal int
+	for {
+		rec, err := rr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		ordinal++
+
+		k := kf(*rec)
+		if k == "" {
+			fmt.Fprintf(os.Stderr, "No key: %s record %d\n", flag.Arg(1), ordinal)
+			continue
+		}
+		if matched[k] {
+			fmt.Fprintf(os.Stderr, "Duplicate: %s at %s record %d\n", k, flag.Arg(1), ordinal)
+			continue
+		}
+		matched[k] = true
+
+		old, ok := before.records[k]
+		if !ok {
+			r.Added(k)
+			continue
+		}
+		r.Compare(k, *old, *rec)
+	}
+
+	for _, k := range before.keys {
+		if !matched[k] {
+			r.Deleted(k)
+		}
+	}
+
+	if format == "json" {
+		b, err := json.MarshalIndent(r.Summary(), "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(string(b))
+		return
+	}
+
+	fmt.Print(r.AsText(list))
+}
+
+// readKeyed reads the records of a MARC file by key. Records that have
+// no key, or the same key as an earlier record, are reported on stderr
+// and skipped
+func readKeyed(marcfile string, kf keyFunc) (kr keyedRecords) {
+
+	kr.records = make(map[string]*marc21.Record)
+
+	fi, err := os.Open(marcfile)
+	if err != nil {
+		log.Fatal(fmt.Printf("File open failed: %q", err))
+	}
+	defer func() {
+		if cerr := fi.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	rr := details.NewRecordReader(fi)
+
+	var ordinal int
+	for {
+		rec, err := rr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		ordinal++
+
+		k := kf(*rec)
+		if k == "" {
+			fmt.Fprintf(os.Stderr, "No key: %s record %d\n", marcfile, ordinal)
+			continue
+		}
+		if _, ok := kr.records[k]; ok {
+			fmt.Fprintf(os.Stderr, "Duplicate: %s at %s record %d\n", k, marcfile, ordinal)
+			continue
+		}
+		kr.records[k] = rec
+		kr.keys = append(kr.keys, k)
+	}
+
+	return kr
+}
+
+func showHelp() {
+	fmt.Println(os.Args[0])
+	fmt.Println("   Compares the leader and control fields of the records in a before and an after MARC (ISO 2709 or MARCXML) file.")
+	fmt.Printf("    Usage: %s [-key cn|001|035] [-format text|json] [-list] <before MARC file> <after MARC file>\n", os.Args[0])
+	fmt.Println()
+	fmt.Println("    Records are matched by 003 and 001 (cn, the default), by 001 alone, or by the first 035 $a.")
+	fmt.Println("    The report counts the added, deleted and changed records and, for each change to a decoded")
+	fmt.Println("    element, the number of records having that change, for example:")
+	fmt.Println()
+	fmt.Println("          1204 records changed 008/39 Cataloging source: blank -> d Other")
+	fmt.Println()
+	fmt.Println("    Added and removed 006 and 007 fields, and changes to the 001, 003, 005, etc., are counted by")
+	fmt.Println("    field. Records that have no key, or the same key as an earlier record in the same file, are")
+	fmt.Println("    reported on stderr and skipped. With -list the added, deleted and changed records are also")
+	fmt.Println("    listed. Use dumprec diff to see the changes to a single record.")
+	fmt.Println()
+	os.Exit(0)
+}
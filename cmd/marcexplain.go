@@ -0,0 +1,200 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	//
+	"github.com/gsiems/go-marc21-details/pkg/details"
+	"github.com/gsiems/go-marc21/pkg/marc21"
+)
+
+func main() {
+
+	var formatName, material, marcfile, cn, output string
+
+	flag.StringVar(&formatName, "format", "bibliography", "The record format (bibliography, holdings, authority, classification or community).")
+	flag.StringVar(&material, "material", "", "The material: the 008 material type (BK, MP, etc.), 006 form of material or 007 category of material, as a code or name.")
+	flag.StringVar(&marcfile, "record", "", "A MARC file to take the record from.")
+	flag.StringVar(&cn, "cn", "", "The control number (001) of the record to use, defaults to the first record.")
+	flag.StringVar(&output, "output", "text", "The output format (text or json).")
+	flag.Usage = showHelp
+	flag.Parse()
+
+	if flag.NArg() == 0 || (output != "text" && output != "json") {
+		showHelp()
+	}
+
+	var l []details.Explanation
+
+	if marcfile != "" {
+		rec := findRecord(marcfile, cn)
+		for _, q := range flag.Args() {
+			x, err := details.ExplainRecord(*rec, q)
+			if err != nil {
+				log.Fatal(err)
+			}
+			l = append(l, x...)
+		}
+	} else {
+		format, ok := parseFormat(formatName)
+		if !ok {
+			showHelp()
+		}
+		for _, q := range flag.Args() {
+			x, err := explainPosition(format, material, q)
+			if err != nil {
+				log.Fatal(err)
+			}
+			l = append(l, x)
+		}
+	}
+
+	if output == "json" {
+		b, err := json.MarshalIndent(l, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(string(b))
+		return
+	}
+
+	for i, x := range l {
+		if i > 0 {
+			fmt.Println()
+		}
+		showExplanation(x)
+	}
+}
+
+// explainPosition explains a "tag/position" query
+func explainPosition(format int, material, q string) (x details.Explanation, err error) {
+
+	p := strings.SplitN(q, "/", 2)
+	if len(p) != 2 {
+		return x, fmt.Errorf("invalid position %q, expected <tag>/<position>", q)
+	}
+	tag := strings.ToUpper(p[0])
+	pos, err := strconv.Atoi(p[1])
+	if err != nil || pos < 0 {
+		return x, fmt.Errorf("invalid position %q, expected <tag>/<position>", q)
+	}
+
+	var code string
+	if material != "" && tag != "LDR" {
+		m, ok := details.FindMaterial(format, tag, material)
+		if !ok {
+			return x, fmt.Errorf("%s: unknown material %q", q, material)
+		}
+		code = m.Code
+	}
+
+	return details.ExplainPosition(format, tag, code, pos)
+}
+
+// findRecord returns the record with the control number, or the first
+// record if there is no control number
+func findRecord(marcfile, cn string) *marc21.Record {
+
+	fi, err := os.Open(marcfile)
+	if err != nil {
+		log.Fatal(fmt.Printf("File open failed: %q", err))
+	}
+	defer func() {
+		if cerr := fi.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	rr := details.NewRecordReader(fi)
+
+	for {
+		rec, err := rr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		if cn == "" || rec.GetControlfield("001") == cn {
+			return rec
+		}
+	}
+
+	log.Fatalf("No record %q found in %s", cn, marcfile)
+	return nil
+}
+
+func showExplanation(x details.Explanation) {
+
+	e := x.Element
+
+	name := x.Field
+	if x.Material != "" {
+		name += " (" + x.Material + ")"
+	}
+	fmt.Printf("%s %s/%02d  %s\n", x.Format, x.Tag, x.Position, name)
+	fmt.Printf("    Element:   %s\n", e.Name)
+	fmt.Printf("    Positions: %s/%s", x.Tag, e.Positions)
+	if e.MultiCode {
+		fmt.Printf(" (up to %d codes of %d characters)", e.Width/e.CodeWidth, e.CodeWidth)
+	}
+	fmt.Println()
+	fmt.Printf("    Key:       %s\n", e.Key)
+
+	if len(e.Codes) > 0 {
+		fmt.Println("    Codes:")
+		for _, c := range e.Codes {
+			fmt.Printf("        %-4s %s\n", strings.Replace(c.Code, " ", "#", -1), c.Label)
+		}
+		if e.RangeLabel != "" {
+			fmt.Printf("        %-4s %s\n", strings.Repeat("n", e.Width), e.RangeLabel)
+		}
+	} else {
+		fmt.Println("    Codes:     none, the element contains data")
+	}
+
+	if x.Text == "" {
+		return
+	}
+	fmt.Printf("    Field:     %s\n", strings.Replace(x.Text, " ", "#", -1))
+	for _, v := range x.Values {
+		fmt.Printf("    Value:     %s/%s %q %s [%s]\n", x.Tag, v.Positions, v.Code, v.Label, v.Status)
+	}
+}
+
+// parseFormat returns the record format for a format name
+func parseFormat(name string) (int, bool) {
+
+	for _, format := range details.Formats() {
+		s := strings.ToLower(details.FormatName(format))
+		if strings.HasPrefix(s, strings.ToLower(name)) {
+			return format, true
+		}
+	}
+
+	return 0, false
+}
+
+func showHelp() {
+	fmt.Println(os.Args[0])
+	fmt.Println("   Explains what is at a position of the leader or of a control field.")
+	fmt.Printf("    Usage: %s [-format <format>] [-material <material>] [-output text|json] <tag>/<position> [...]\n", os.Args[0])
+	fmt.Printf("           %s -record <MARC file> [-cn <001>] [-output text|json] <tag>/<position>|<element key> [...]\n", os.Args[0])
+	fmt.Println()
+	fmt.Println("    Shows the element at the position, its full position range and the codes defined for it and,")
+	fmt.Println("    with -record, the value that the record has there. The record file may be ISO 2709 or MARCXML.")
+	fmt.Println("    The format may be abbreviated. For example:")
+	fmt.Println()
+	fmt.Printf("      %s -material maps 008/29\n", os.Args[0])
+	fmt.Printf("      %s -material \"sound recording\" 007/04\n", os.Args[0])
+	fmt.Printf("      %s -format auth LDR/18\n", os.Args[0])
+	fmt.Printf("      %s -record records.mrc -cn ocm12345 008/23 007_2/04\n", os.Args[0])
+	fmt.Println()
+	os.Exit(0)
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	//
+	"github.com/gsiems/go-marc21-details/pkg/details"
+)
+
+func main() {
+
+	var list bool
+
+	flag.BoolVar(&list, "list", false, "List the control numbers (001) of the matching records rather than writing the records.")
+	flag.Usage = showHelp
+	flag.Parse()
+
+	marcfile := flag.Arg(0)
+	expr := flag.Arg(1)
+
+	if marcfile == "" || expr == "" || flag.NArg() > 2 {
+		showHelp()
+	}
+
+	f, err := details.ParseFilter(expr)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fi, err := os.Open(marcfile)
+	if err != nil {
+		log.Fatal(fmt.Printf("File open failed: %q", err))
+	}
+	defer func() {
+		if cerr := fi.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	rr := details.NewRecordReader(fi)
+
+	for {
+		rec, err := rr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if !f.Match(*rec) {
+			continue
+		}
+
+		if list {
+			fmt.Println(rec.GetControlfield("001"))
+			continue
+		}
+
+		rawRec, err := rec.RecordAsMARC()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		_, err = os.Stdout.Write(rawRec)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+func showHelp() {
+	fmt.Println(os.Args[0])
+	fmt.Println("   Selects the records in a MARC file whose decoded leader and control field elements match a filter.")
+	fmt.Printf("    Usage: %s [-list] <MARC file to filter> <filter>\n", os.Args[0])
+	fmt.Println()
+	fmt.Println("    The MARC file may be ISO 2709 or MARCXML. The matching records are written to stdout as")
+	fmt.Println("    ISO 2709, or with -list their control numbers are listed. Filters consist of conditions on")
+	fmt.Println("    the element keys (see dumprec -format json) using =, !=, in (...) and not in (...), combined")
+	fmt.Println("    with and, or, not and parentheses. Values match either the code or the label. For example:")
+	fmt.Println()
+	fmt.Println(`      008.bk.target_audience = "Juvenile"`)
+	fmt.Println(`      ldr.encoding_level in (3, 5, 7)`)
+	fmt.Println(`      007.category = "Videorecording" and 007.vir.videorecording_format = "DVD"`)
+	fmt.Println()
+	os.Exit(0)
+}
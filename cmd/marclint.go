@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	//
+	"github.com/gsiems/go-marc21-details/pkg/details"
+)
+
+func main() {
+
+	var config, threshold, format string
+	var listRules bool
+
+	flag.StringVar(&config, "config", "", "A file of rule severities.")
+	flag.StringVar(&threshold, "threshold", "error", "Exit with status 1 if there are issues at or above this severity (error, warning, info or off).")
+	flag.StringVar(&format, "format", "text", "The output format (text or json).")
+	flag.BoolVar(&listRules, "rules", false, "List the rules and their severities.")
+	flag.Usage = showHelp
+	flag.Parse()
+
+	v := details.NewValidator()
+	if config != "" {
+		err := v.LoadConfig(config)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if listRules {
+		for _, r := range v.Rules() {
+			fmt.Printf("%-24s %-8s %s\n", r.ID, r.Severity, r.Description)
+		}
+		os.Exit(0)
+	}
+
+	limit, err := details.ParseSeverity(threshold)
+	switch {
+	case err != nil:
+		usageError(err.Error())
+	case format != "text" && format != "json":
+		usageError(fmt.Sprintf("unknown format %q", format))
+	case flag.NArg() == 0:
+		usageError("no MARC files given")
+	}
+
+	var worst details.Severity
+	for _, marcfile := range flag.Args() {
+		s := lintFile(v, marcfile, format)
+		if s > worst {
+			worst = s
+		}
+	}
+
+	if limit != details.SeverityOff && worst >= limit {
+		os.Exit(1)
+	}
+}
+
+// lintFile validates the records of a MARC file and returns the
+// highest severity found
+func lintFile(v *details.Validator, marcfile, format string) (worst details.Severity) {
+
+	fi, err := os.Open(marcfile)
+	if err != nil {
+		log.Fatal(fmt.Printf("File open failed: %q", err))
+	}
+	defer func() {
+		if cerr := fi.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	r := bufio.NewReader(fi)
+
+	// MARCXML has no raw record structure to check so only the leader
+	// and fields are validated
+	var xr *details.XMLReader
+	if details.IsMARCXML(r) {
+		xr = details.NewXMLReader(r)
+	}
+
+	var ordinal int
+	for {
+		var issues []details.Issue
+		if xr != nil {
+			rec, err := xr.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				log.Fatal(err)
+			}
+			issues = v.Validate(*rec)
+		} else {
+			rawRec, err := details.ReadRawRecord(r)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				log.Fatal(err)
+			}
+			issues, _ = v.ValidateRaw(rawRec)
+		}
+		ordinal++
+
+		for _, is := range issues {
+			if is.Severity > worst {
+				worst = is.Severity
+			}
+			writeIssue(marcfile, ordinal, is, format)
+		}
+	}
+
+	return worst
+}
+
+func writeIssue(marcfile string, ordinal int, is details.Issue, format string) {
+
+	if format == "json" {
+		b, err := json.Marshal(struct {
+			File    string `json:"file"`
+			Ordinal int    `json:"ordinal"`
+			details.Issue
+		}{marcfile, ordinal, is})
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(string(b))
+		return
+	}
+
+	fmt.Printf("%s:%d: %s\n", marcfile, ordinal, is)
+}
+
+func showHelp() {
+	fmt.Println(os.Args[0])
+	fmt.Println("   Validates the structure, leader and control fields of the records in one or more MARC files.")
+	fmt.Printf("    Usage: %s [-config <file>] [-threshold error|warning|info|off] [-format text|json] <MARC file> [<MARC file> ...]\n", os.Args[0])
+	fmt.Printf("           %s [-config <file>] -rules\n", os.Args[0])
+	fmt.Println()
+	fmt.Println("    Issues are written one per line as <file>:<record>: <001> <tag>/<positions> <severity>: <message> (<rule>)")
+	fmt.Println("    or, with -format json, as one JSON object per line. The exit status is 2 for a usage error and 1 if any issue is at or")
+	fmt.Println("    above the threshold severity. The MARC files may be ISO 2709 or MARCXML; the structure of the")
+	fmt.Println("    raw records is only validated for ISO 2709.")
+	fmt.Println()
+	fmt.Println("    The config file lists one rule per line followed by its severity (error, warning, info or off).")
+	fmt.Println("    Blank lines and lines starting with # are ignored. Use -rules to list the rules.")
+	fmt.Println()
+}
+
+// usageError reports a command line error and exits with status 2, as
+// the flag package does for an unknown flag. Only -h exits with 0.
+func usageError(msg string) {
+	fmt.Fprintln(os.Stderr, msg)
+	showHelp()
+	os.Exit(2)
+}
@@ -0,0 +1,868 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	//
+	"github.com/gsiems/go-marc21-details/pkg/details"
+	"github.com/gsiems/go-marc21/pkg/marc21"
+)
+
+// command is a REPL command
+type command struct {
+	name  string
+	args  string
+	help  string
+	run   func(s *session, args []string) error
+	comps func(s *session) []string
+}
+
+// session is the state of the REPL
+type session struct {
+	file    string
+	records []*marc21.Record
+	titles  []string
+	cur     int
+	changed bool
+	v       *details.Validator
+	keys    []string
+}
+
+var commands []command
+
+func init() {
+	commands = []command{
+		{"load", "<MARC file>", "Load the records of a MARC (ISO 2709 or MARCXML) file", cmdLoad, nil},
+		{"list", "[<first> [<count>]]", "List the records by 001 and title", cmdList, nil},
+		{"record", "<number>|<001>", "Select a record", cmdRecord, nil},
+		{"next", "", "Select the next record", cmdNext, nil},
+		{"prev", "", "Select the previous record", cmdPrev, nil},
+		{"show", "[<tag>]", "Show the decoded leader and control fields, or one of them (ldr, 006, 007[2], 008)", cmdShow, nil},
+		{"get", "<key>|<tag>/<position>", "Show the value of an element", cmdGet, elementKeys},
+		{"set", "<key>|<tag>/<position> <value>", "Set an element to a code or label", cmdSet, elementKeys},
+		{"validate", "", "Validate the selected record", cmdValidate, nil},
+		{"explain", "<tag>/<position> [<format>] [<material>]", "Explain a position, for example: explain 008/24 bk", cmdExplain, nil},
+		{"elements", "<tag> [<format>] [<material>]", "List the elements of a field", cmdElements, nil},
+		{"codes", "<query>", "Search the codes (up to three characters) or labels", cmdCodes, nil},
+		{"write", "<MARC file>", "Write the records, with any changes, to a file", cmdWrite, nil},
+		{"help", "", "List the commands", cmdHelp, nil},
+		{"quit", "", "Exit", nil, nil},
+	}
+}
+
+func main() {
+
+	flag.Usage = showHelp
+	flag.Parse()
+
+	if flag.NArg() > 1 {
+		showHelp()
+	}
+
+	s := &session{v: details.NewValidator(), keys: allElementKeys()}
+
+	if flag.NArg() == 1 {
+		err := cmdLoad(s, []string{flag.Arg(0)})
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	in := newLineReader(s)
+	defer in.close()
+
+	for {
+		line, err := in.readLine(s.prompt())
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		args := strings.Fields(line)
+		if len(args) == 0 {
+			continue
+		}
+
+		// Field names are shortcuts for show
+		if isFieldName(args[0]) {
+			args = append([]string{"show"}, args...)
+		}
+
+		c, ok := findCommand(args[0])
+		if !ok {
+			fmt.Printf("Unknown command %q, try help\n", args[0])
+			continue
+		}
+		if c.name == "quit" {
+			break
+		}
+		err = c.run(s, args[1:])
+		if err != nil {
+			fmt.Println("Error:", err)
+		}
+	}
+
+	if s.changed {
+		fmt.Println("Note: the changes have not been written")
+	}
+}
+
+func (s *session) prompt() string {
+	if len(s.records) == 0 {
+		return "marc> "
+	}
+	return fmt.Sprintf("marc [%d %s]> ", s.cur+1, s.records[s.cur].GetControlfield("001"))
+}
+
+// record returns the selected record
+func (s *session) record() (*marc21.Record, error) {
+	if len(s.records) == 0 {
+		return nil, fmt.Errorf("no records, use load")
+	}
+	return s.records[s.cur], nil
+}
+
+func findCommand(name string) (c command, ok bool) {
+
+	// Unique prefixes of the command names are accepted
+	var l []command
+	for _, c := range commands {
+		if c.name == name {
+			return c, true
+		}
+		if strings.HasPrefix(c.name, name) {
+			l = append(l, c)
+		}
+	}
+	if len(l) == 1 {
+		return l[0], true
+	}
+
+	return c, false
+}
+
+func isFieldName(s string) bool {
+	s = strings.ToLower(s)
+	if s == "ldr" {
+		return true
+	}
+	if i := strings.IndexAny(s, "[_"); i > 0 {
+		s = s[:i]
+	}
+	return len(s) == 3 && strings.HasPrefix(s, "00")
+}
+
+func cmdLoad(s *session, args []string) error {
+
+	if len(args) != 1 {
+		return fmt.Errorf("usage: load <MARC file>")
+	}
+	if s.changed {
+		fmt.Println("Note: the changes to the previous file have been discarded")
+	}
+
+	fi, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer fi.Close()
+
+	var records []*marc21.Record
+	var titles []string
+
+	rr := details.NewRecordReader(fi)
+	for {
+		rec, err := rr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("record %d: %s", len(records)+1, err)
+		}
+		records = append(records, rec)
+		titles = append(titles, recordTitle(*rec))
+	}
+
+	s.file = args[0]
+	s.records = records
+	s.titles = titles
+	s.cur = 0
+	s.changed = false
+
+	fmt.Printf("Loaded %d records from %s\n", len(records), args[0])
+
+	return nil
+}
+
+// recordTitle returns the title proper (245 $a $b) of a record
+func recordTitle(rec marc21.Record) string {
+
+	var t []string
+	for _, df := range rec.GetDatafields("245") {
+		for _, sf := range df.GetSubfields("ab") {
+			t = append(t, sf.GetText())
+		}
+	}
+
+	return strings.TrimRight(strings.Join(t, " "), " /:;,.")
+}
+
+func cmdList(s *session, args []string) error {
+
+	first, count := 1, 20
+	var err error
+	if len(args) > 0 {
+		first, err = strconv.Atoi(args[0])
+		if err != nil || first < 1 {
+			return fmt.Errorf("invalid record number %q", args[0])
+		}
+	}
+	if len(args) > 1 {
+		count, err = strconv.Atoi(args[1])
+		if err != nil || count < 1 {
+			return fmt.Errorf("invalid count %q", args[1])
+		}
+	}
+
+	for i := first - 1; i < len(s.records) && i < first-1+count; i++ {
+		mark := " "
+		if i == s.cur {
+			mark = "*"
+		}
+		fmt.Printf("%s%6d  %-16s %s\n", mark, i+1, s.records[i].GetControlfield("001"), s.titles[i])
+	}
+
+	return nil
+}
+
+func cmdRecord(s *session, args []string) error {
+
+	if len(args) != 1 {
+		return fmt.Errorf("usage: record <number>|<001>")
+	}
+	if len(s.records) == 0 {
+		return fmt.Errorf("no records, use load")
+	}
+
+	for i, rec := range s.records {
+		if rec.GetControlfield("001") == args[0] {
+			s.cur = i
+			return nil
+		}
+	}
+
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n < 1 || n > len(s.records) {
+		return fmt.Errorf("no record %q", args[0])
+	}
+	s.cur = n - 1
+
+	return nil
+}
+
+func cmdNext(s *session, args []string) error {
+	if s.cur >= len(s.records)-1 {
+		return fmt.Errorf("no next record")
+	}
+	s.cur++
+	return nil
+}
+
+func cmdPrev(s *session, args []string) error {
+	if s.cur == 0 {
+		return fmt.Errorf("no previous record")
+	}
+	s.cur--
+	return nil
+}
+
+func cmdShow(s *session, args []string) error {
+
+	rec, err := s.record()
+	if err != nil {
+		return err
+	}
+
+	var tag string
+	occ := 0
+	if len(args) > 0 {
+		tag = strings.ToUpper(args[0])
+		// 007[2] or 007_2
+		if i := strings.IndexAny(tag, "[_"); i > 0 {
+			occ, err = strconv.Atoi(strings.Trim(tag[i+1:], "]"))
+			if err != nil || occ < 1 {
+				return fmt.Errorf("invalid occurrence in %q", args[0])
+			}
+			tag = tag[:i]
+		}
+	}
+
+	rd := details.DescribeRecord(*rec)
+	if tag == "" {
+		fmt.Printf("%s %s  %s\n", rd.Format, rd.Material, s.titles[s.cur])
+	}
+
+	var n int
+	var found bool
+	for _, fd := range rd.Fields {
+		if tag != "" && fd.Tag != tag {
+			continue
+		}
+		n++
+		if occ > 0 && n != occ {
+			continue
+		}
+		found = true
+		showField(fd)
+	}
+	if !found {
+		return fmt.Errorf("the record has no %s", args[0])
+	}
+
+	return nil
+}
+
+func showField(fd details.FieldDesc) {
+
+	fmt.Printf("%-4s %s", fd.Tag, strings.Replace(fd.Text, " ", "#", -1))
+	if fd.Material != "" {
+		fmt.Printf("  (%s)", fd.Material)
+	}
+	fmt.Println()
+
+	for _, ed := range fd.Elements {
+		name := ed.Name
+		if ed.Seq > 0 {
+			name += " " + strconv.Itoa(ed.Seq)
+		}
+		fmt.Printf("    %-5s %-40s %-4s %s", ed.Positions, name, strings.Replace(ed.Code, " ", "#", -1), ed.Label)
+		if ed.Status == details.StatusUndefined || ed.Status == details.StatusObsolete || ed.Status == details.StatusMissing {
+			fmt.Printf(" [%s]", ed.Status)
+		}
+		fmt.Println()
+	}
+}
+
+func cmdGet(s *session, args []string) error {
+
+	if len(args) != 1 {
+		return fmt.Errorf("usage: get <key>|<tag>/<position>")
+	}
+	rec, err := s.record()
+	if err != nil {
+		return err
+	}
+
+	l, err := details.ExplainRecord(*rec, args[0])
+	if err != nil {
+		return err
+	}
+	for _, x := range l {
+		for _, v := range x.Values {
+			fmt.Printf("%s/%s %s: %s %s [%s]\n", x.Tag, v.Positions, v.Name, strings.Replace(v.Code, " ", "#", -1), v.Label, v.Status)
+		}
+	}
+
+	return nil
+}
+
+func cmdSet(s *session, args []string) error {
+
+	if len(args) < 2 {
+		return fmt.Errorf("usage: set <key>|<tag>/<position> <value>")
+	}
+	rec, err := s.record()
+	if err != nil {
+		return err
+	}
+
+	_, err = details.SetElement(rec, args[0], strings.Join(args[1:], " "))
+	if err != nil {
+		return err
+	}
+	s.changed = true
+
+	return cmdGet(s, args[:1])
+}
+
+func cmdValidate(s *session, args []string) error {
+
+	rec, err := s.record()
+	if err != nil {
+		return err
+	}
+
+	// Validate the record as it would be written
+	rawRec, err := rec.RecordAsMARC()
+	if err != nil {
+		return err
+	}
+	issues, _ := s.v.ValidateRaw(rawRec)
+	for _, issue := range issues {
+		fmt.Println(issue)
+	}
+	if len(issues) == 0 {
+		fmt.Println("No issues")
+	}
+
+	return nil
+}
+
+// formatAndMaterial interprets the optional format and material
+// arguments of explain and elements
+func (s *session) formatAndMaterial(tag string, args []string) (format int, code string, err error) {
+
+	format = marc21.Bibliography
+	if rec, err := s.record(); err == nil {
+		format = rec.RecordFormat()
+	}
+
+	var material string
+	for _, a := range args {
+		if f, ok := parseFormat(a); ok && len(a) >= 3 {
+			format = f
+			continue
+		}
+		material = a
+	}
+
+	if material != "" && tag != "LDR" {
+		m, ok := details.FindMaterial(format, tag, material)
+		if !ok {
+			return format, code, fmt.Errorf("unknown %s %s material %q", details.FormatName(format), tag, material)
+		}
+		code = m.Code
+	}
+
+	return format, code, nil
+}
+
+func cmdExplain(s *session, args []string) error {
+
+	if len(args) == 0 {
+		return fmt.Errorf("usage: explain <tag>/<position> [<format>] [<material>]")
+	}
+
+	// With no format or material, explain the selected record
+	if rec, err := s.record(); err == nil && len(args) == 1 {
+		l, err := details.ExplainRecord(*rec, args[0])
+		if err != nil {
+			return err
+		}
+		for _, x := range l {
+			showExplanation(x)
+		}
+		return nil
+	}
+
+	p := strings.SplitN(args[0], "/", 2)
+	if len(p) != 2 {
+		return fmt.Errorf("expected <tag>/<position>, got %q", args[0])
+	}
+	tag := strings.ToUpper(p[0])
+	pos, err := strconv.Atoi(p[1])
+	if err != nil {
+		return fmt.Errorf("expected <tag>/<position>, got %q", args[0])
+	}
+
+	format, code, err := s.formatAndMaterial(tag, args[1:])
+	if err != nil {
+		return err
+	}
+
+	x, err := details.ExplainPosition(format, tag, code, pos)
+	if err != nil {
+		return err
+	}
+	showExplanation(x)
+
+	return nil
+}
+
+func showExplanation(x details.Explanation) {
+
+	e := x.Element
+	name := x.Field
+	if x.Material != "" {
+		name += " (" + x.Material + ")"
+	}
+	fmt.Printf("%s %s/%s %s: %s (%s)\n", x.Format, x.Tag, e.Positions, name, e.Name, e.Key)
+	for _, c := range e.Codes {
+		fmt.Printf("    %-4s %s\n", strings.Replace(c.Code, " ", "#", -1), c.Label)
+	}
+	if e.RangeLabel != "" {
+		fmt.Printf("    %-4s %s\n", strings.Repeat("n", e.Width), e.RangeLabel)
+	}
+	for _, v := range x.Values {
+		fmt.Printf("  Value %s/%s: %s %s [%s]\n", x.Tag, v.Positions, strings.Replace(v.Code, " ", "#", -1), v.Label, v.Status)
+	}
+}
+
+func cmdElements(s *session, args []string) error {
+
+	if len(args) == 0 {
+		return fmt.Errorf("usage: elements <tag> [<format>] [<material>]")
+	}
+	tag := strings.ToUpper(args[0])
+
+	format, code, err := s.formatAndMaterial(tag, args[1:])
+	if err != nil {
+		return err
+	}
+
+	fd, ok := details.GetFieldDef(format, tag, code)
+	if !ok {
+		var l []string
+		for _, m := range details.Materials(format, tag) {
+			l = append(l, m.Code+" ("+m.Name+")")
+		}
+		return fmt.Errorf("specify one of: %s", strings.Join(l, ", "))
+	}
+
+	fmt.Printf("%s %s %s\n", fd.Format, fd.Tag, fd.Name)
+	for _, e := range fd.Elements {
+		multi := ""
+		if e.IsMultiCode() {
+			multi = fmt.Sprintf(" (up to %d codes)", e.Width/e.CodeWidth)
+		}
+		fmt.Printf("    %-5s %-40s %s%s\n", e.Positions(), e.Name, fd.Key(e), multi)
+	}
+
+	return nil
+}
+
+func cmdCodes(s *session, args []string) error {
+
+	if len(args) == 0 {
+		return fmt.Errorf("usage: codes <query>")
+	}
+	q := strings.Join(args, " ")
+
+	opt := details.SearchOptions{Codes: len(q) <= 3, Labels: len(q) > 3}
+	if rec, err := s.record(); err == nil {
+		opt.Format = rec.RecordFormat()
+	}
+
+	l := details.SearchCodes(q, opt)
+	for _, cm := range l {
+		field := cm.Field
+		if cm.Material != "" {
+			field += " (" + cm.Material + ")"
+		}
+		fmt.Printf("%s/%-5s %-4s %s; %s; %s\n", cm.Tag, cm.Positions, strings.Replace(cm.Code, " ", "#", -1), cm.Label, cm.Element, field)
+	}
+	if len(l) == 0 {
+		fmt.Println("No matching codes")
+	}
+
+	return nil
+}
+
+func cmdWrite(s *session, args []string) error {
+
+	if len(args) != 1 {
+		return fmt.Errorf("usage: write <MARC file>")
+	}
+	if len(s.records) == 0 {
+		return fmt.Errorf("no records, use load")
+	}
+
+	var b []byte
+	for i, rec := range s.records {
+		rawRec, err := rec.RecordAsMARC()
+		if err != nil {
+			return fmt.Errorf("record %d: %s", i+1, err)
+		}
+		b = append(b, rawRec...)
+	}
+
+	err := ioutil.WriteFile(args[0], b, 0644)
+	if err != nil {
+		return err
+	}
+	s.changed = false
+	fmt.Printf("Wrote %d records to %s\n", len(s.records), args[0])
+
+	return nil
+}
+
+func cmdHelp(s *session, args []string) error {
+
+	for _, c := range commands {
+		fmt.Printf("  %-10s %-44s %s\n", c.name, c.args, c.help)
+	}
+	fmt.Println()
+	fmt.Println("  ldr, 006, 007, 007[2], 008, etc. are short for show. Commands may be abbreviated.")
+	fmt.Println("  Tab completes the commands and, for get and set, the element keys.")
+
+	return nil
+}
+
+// parseFormat returns the record format for a format name
+func parseFormat(name string) (int, bool) {
+
+	for _, format := range details.Formats() {
+		s := strings.ToLower(details.FormatName(format))
+		if strings.HasPrefix(s, strings.ToLower(name)) {
+			return format, true
+		}
+	}
+
+	return 0, false
+}
+
+// allElementKeys returns the element keys, with and without the
+// material, of all of the definitions
+func allElementKeys() []string {
+
+	seen := make(map[string]bool)
+	for _, format := range details.Formats() {
+		for _, tag := range details.Tags(format) {
+			for _, m := range details.Materials(format, tag) {
+				fd, ok := details.GetFieldDef(format, tag, m.Code)
+				if !ok {
+					continue
+				}
+				for _, e := range fd.Elements {
+					seen[fd.Key(e)] = true
+					seen[strings.ToLower(fd.Tag)+"."+e.ID()] = true
+				}
+			}
+		}
+	}
+
+	var l []string
+	for k := range seen {
+		l = append(l, k)
+	}
+	sort.Strings(l)
+
+	return l
+}
+
+func elementKeys(s *session) []string {
+	return s.keys
+}
+
+// complete returns the completions for the last word of a line
+func complete(s *session, line string) []string {
+
+	words := strings.Fields(line)
+	if strings.HasSuffix(line, " ") || len(words) == 0 {
+		words = append(words, "")
+	}
+	word := words[len(words)-1]
+
+	var candidates []string
+	switch len(words) {
+	case 1:
+		for _, c := range commands {
+			candidates = append(candidates, c.name)
+		}
+	case 2:
+		c, ok := findCommand(words[0])
+		if !ok || c.comps == nil {
+			return nil
+		}
+		candidates = c.comps(s)
+	default:
+		return nil
+	}
+
+	var l []string
+	for _, c := range candidates {
+		if strings.HasPrefix(c, strings.ToLower(word)) {
+			l = append(l, c)
+		}
+	}
+
+	return l
+}
+
+// lineReader reads lines from the terminal, with history and tab
+// completion, or from stdin when it is not a terminal
+type lineReader struct {
+	s       *session
+	raw     bool
+	state   string
+	in      *bufio.Reader
+	history []string
+}
+
+func newLineReader(s *session) *lineReader {
+
+	lr := &lineReader{s: s, in: bufio.NewReader(os.Stdin)}
+
+	state, err := stty("-g")
+	if err != nil {
+		return lr
+	}
+	_, err = stty("-icanon", "-echo", "min", "1")
+	if err != nil {
+		return lr
+	}
+	lr.raw = true
+	lr.state = strings.TrimSpace(state)
+
+	return lr
+}
+
+func (lr *lineReader) close() {
+	if lr.raw {
+		_, _ = stty(lr.state)
+	}
+}
+
+func stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	out, err := cmd.Output()
+	return string(out), err
+}
+
+func (lr *lineReader) readLine(prompt string) (string, error) {
+
+	if !lr.raw {
+		fmt.Print(prompt)
+		line, err := lr.in.ReadString('\n')
+		if err == io.EOF && line != "" {
+			err = nil
+		}
+		return strings.TrimSpace(line), err
+	}
+
+	var line []rune
+	hist := len(lr.history)
+
+	redraw := func() {
+		fmt.Printf("\r\x1b[K%s%s", prompt, string(line))
+	}
+	redraw()
+
+	for {
+		r, _, err := lr.in.ReadRune()
+		if err != nil {
+			return "", err
+		}
+
+		switch r {
+		case '\r', '\n':
+			fmt.Println()
+			s := strings.TrimSpace(string(line))
+			if s != "" && (len(lr.history) == 0 || lr.history[len(lr.history)-1] != s) {
+				lr.history = append(lr.history, s)
+			}
+			return s, nil
+
+		case 4: // Ctrl-D
+			if len(line) == 0 {
+				fmt.Println()
+				return "", io.EOF
+			}
+
+		case 3: // Ctrl-C
+			fmt.Println("^C")
+			line = nil
+
+		case 127, '\b':
+			if len(line) > 0 {
+				line = line[:len(line)-1]
+			}
+
+		case '\t':
+			line = lr.complete(line, prompt)
+
+		case 0x1b:
+			// Up and down arrows go through the history, other escape
+			// sequences are ignored
+			b1, _ := lr.in.ReadByte()
+			b2, _ := lr.in.ReadByte()
+			if b1 != '[' {
+				break
+			}
+			switch {
+			case b2 == 'A' && hist > 0:
+				hist--
+				line = []rune(lr.history[hist])
+			case b2 == 'B' && hist < len(lr.history)-1:
+				hist++
+				line = []rune(lr.history[hist])
+			case b2 == 'B':
+				hist = len(lr.history)
+				line = nil
+			}
+
+		default:
+			if r >= ' ' {
+				line = append(line, r)
+			}
+		}
+		redraw()
+	}
+}
+
+// complete completes the last word of the line, listing the candidates
+// if there is more than one
+func (lr *lineReader) complete(line []rune, prompt string) []rune {
+
+	s := string(line)
+	l := complete(lr.s, s)
+	if len(l) == 0 {
+		return line
+	}
+
+	word := s[strings.LastIndexAny(s, " ")+1:]
+
+	// The longest common prefix of the candidates
+	prefix := l[0]
+	for _, c := range l[1:] {
+		for !strings.HasPrefix(c, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+
+	if len(l) == 1 {
+		return []rune(s[:len(s)-len(word)] + prefix + " ")
+	}
+	if len(prefix) > len(word) {
+		return []rune(s[:len(s)-len(word)] + prefix)
+	}
+
+	fmt.Println()
+	if len(l) > 60 {
+		fmt.Printf("%d possibilities\n", len(l))
+		return line
+	}
+	fmt.Println(strings.Join(l, "  "))
+
+	return line
+}
+
+func showHelp() {
+	fmt.Println(os.Args[0])
+	fmt.Println("   An interactive shell for exploring MARC records and the leader and control field definitions.")
+	fmt.Printf("    Usage: %s [<MARC file>]\n", os.Args[0])
+	fmt.Println()
+	fmt.Println("    Records may be loaded and selected, their leader and control fields shown and edited, and")
+	fmt.Println("    validated; positions may be explained and codes searched. Type help at the prompt for the")
+	fmt.Println("    list of commands. MARC files may be ISO 2709 or MARCXML. For example:")
+	fmt.Println()
+	fmt.Println("      load records.mrc")
+	fmt.Println("      record ocm12345")
+	fmt.Println("      007[2]")
+	fmt.Println("      set 008.form_of_item o")
+	fmt.Println("      explain 008/24 bk")
+	fmt.Println()
+	os.Exit(0)
+}
@@ -0,0 +1,340 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"html/template"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	//
+	"github.com/gsiems/go-marc21-details/pkg/details"
+	"github.com/gsiems/go-marc21/pkg/marc21"
+)
+
+// maxBody being the largest request accepted
+const maxBody = 32 << 20
+
+// decodedRecord is the JSON response for a single record
+type decodedRecord struct {
+	details.RecordDesc
+	Summary    string          `json:"summary"`
+	Datafields []datafieldDesc `json:"datafields,omitempty"`
+	Issues     []details.Issue `json:"issues,omitempty"`
+}
+
+type datafieldDesc struct {
+	Tag       string         `json:"tag"`
+	Name      string         `json:"name,omitempty"`
+	Ind1      string         `json:"ind1"`
+	Ind2      string         `json:"ind2"`
+	Subfields []subfieldDesc `json:"subfields"`
+}
+
+type subfieldDesc struct {
+	Code string `json:"code"`
+	Text string `json:"text"`
+}
+
+var validator = details.NewValidator()
+
+func main() {
+
+	var addr string
+
+	flag.StringVar(&addr, "addr", "localhost:8021", "The address to listen on.")
+	flag.Usage = showHelp
+	flag.Parse()
+
+	if flag.NArg() > 0 {
+		showHelp()
+	}
+
+	http.HandleFunc("/", formHandler)
+	http.HandleFunc("/decode", decodeHandler)
+
+	log.Printf("Listening on http://%s/", addr)
+	log.Fatal(http.ListenAndServe(addr, nil))
+}
+
+func formHandler(w http.ResponseWriter, r *http.Request) {
+
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	err := formTemplate.Execute(w, nil)
+	if err != nil {
+		log.Print(err)
+	}
+}
+
+// decodeHandler decodes the records in the request body, or in the
+// "record" field or "file" upload of a form
+func decodeHandler(w http.ResponseWriter, r *http.Request) {
+
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		httpError(w, http.StatusMethodNotAllowed, errors.New("use POST"))
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxBody)
+
+	data, err := requestData(r)
+	if err != nil {
+		httpError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	l, err := decodeRecords(data)
+	if err != nil {
+		httpError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	err = enc.Encode(l)
+	if err != nil {
+		log.Print(err)
+	}
+}
+
+// requestData returns the record data of the request
+func requestData(r *http.Request) ([]byte, error) {
+
+	ct := r.Header.Get("Content-Type")
+
+	if strings.HasPrefix(ct, "multipart/form-data") {
+		err := r.ParseMultipartForm(maxBody)
+		if err != nil {
+			return nil, err
+		}
+		f, _, err := r.FormFile("file")
+		if err == nil {
+			defer f.Close()
+			b, err := ioutil.ReadAll(f)
+			if err != nil || len(bytes.TrimSpace(b)) > 0 {
+				return b, err
+			}
+		}
+		return []byte(r.FormValue("record")), nil
+	}
+
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Clients such as curl send a form content type by default so the
+	// body is only taken as a form if it has a record field
+	if strings.HasPrefix(ct, "application/x-www-form-urlencoded") {
+		if v, err := url.ParseQuery(string(b)); err == nil && v.Get("record") != "" {
+			return []byte(v.Get("record")), nil
+		}
+	}
+
+	return b, nil
+}
+
+// decodeRecords decodes binary MARC, MARCXML or MARC-in-JSON records
+func decodeRecords(data []byte) (l []decodedRecord, err error) {
+
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return l, errors.New("no record data")
+	}
+
+	switch trimmed[0] {
+	case '<':
+		recs, err := details.ParseXML(trimmed)
+		if err != nil {
+			return l, fmt.Errorf("MARCXML: %s", err)
+		}
+		for _, rec := range recs {
+			l = append(l, describe(*rec, validator.Validate(*rec)))
+		}
+
+	case '{', '[':
+		recs, err := parseJSON(trimmed)
+		if err != nil {
+			return l, fmt.Errorf("MARC-in-JSON: %s", err)
+		}
+		for _, rec := range recs {
+			l = append(l, describe(*rec, validator.Validate(*rec)))
+		}
+
+	default:
+		// Pasted records may have lost the trailing record terminator
+		r := bufio.NewReader(bytes.NewReader(data))
+		for {
+			rawRec, err := details.ReadRawRecord(r)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return l, err
+			}
+			issues, rec := validator.ValidateRaw(rawRec)
+			if rec == nil {
+				l = append(l, decodedRecord{Issues: issues})
+				continue
+			}
+			l = append(l, describe(*rec, issues))
+		}
+	}
+
+	if len(l) == 0 {
+		return l, errors.New("no records found")
+	}
+
+	return l, nil
+}
+
+// describe returns the decoded record
+func describe(rec marc21.Record, issues []details.Issue) decodedRecord {
+
+	d := decodedRecord{
+		RecordDesc: details.DescribeRecord(rec),
+		Summary:    details.Summarize(rec, details.SummaryVerbose),
+		Issues:     issues,
+	}
+
+	format := rec.RecordFormat()
+	for _, df := range rec.Datafields {
+		dd := datafieldDesc{
+			Tag:  df.Tag,
+			Name: details.DatafieldName(format, df.Tag),
+			Ind1: df.Ind1,
+			Ind2: df.Ind2,
+		}
+		for _, sf := range df.Subfields {
+			dd.Subfields = append(dd.Subfields, subfieldDesc{Code: sf.Code, Text: sf.Text})
+		}
+		d.Datafields = append(d.Datafields, dd)
+	}
+
+	return d
+}
+
+// jsonRecord is a MARC-in-JSON record
+// (https://rossfsinger.com/blog/2010/09/a-proposal-to-serialize-marc-in-json/)
+type jsonRecord struct {
+	Leader string                       `json:"leader"`
+	Fields []map[string]json.RawMessage `json:"fields"`
+}
+
+type jsonDatafield struct {
+	Ind1      string              `json:"ind1"`
+	Ind2      string              `json:"ind2"`
+	Subfields []map[string]string `json:"subfields"`
+}
+
+// parseJSON parses a MARC-in-JSON record or array of records
+func parseJSON(data []byte) (l []*marc21.Record, err error) {
+
+	var jl []jsonRecord
+	if data[0] == '[' {
+		err = json.Unmarshal(data, &jl)
+	} else {
+		var jr jsonRecord
+		err = json.Unmarshal(data, &jr)
+		jl = append(jl, jr)
+	}
+	if err != nil {
+		return l, err
+	}
+
+	for i, jr := range jl {
+		if jr.Leader == "" {
+			return l, fmt.Errorf("record %d: no leader", i+1)
+		}
+		rec := &marc21.Record{Leader: marc21.Leader{Text: jr.Leader}}
+
+		for _, f := range jr.Fields {
+			for tag, v := range f {
+				var text string
+				if json.Unmarshal(v, &text) == nil {
+					rec.Controlfields = append(rec.Controlfields, &marc21.Controlfield{Tag: tag, Text: text})
+					continue
+				}
+
+				var jd jsonDatafield
+				err = json.Unmarshal(v, &jd)
+				if err != nil {
+					return l, fmt.Errorf("record %d field %s: %s", i+1, tag, err)
+				}
+				df := &marc21.Datafield{Tag: tag, Ind1: jd.Ind1, Ind2: jd.Ind2}
+				for _, sf := range jd.Subfields {
+					for code, text := range sf {
+						df.Subfields = append(df.Subfields, &marc21.Subfield{Code: code, Text: text})
+					}
+				}
+				rec.Datafields = append(rec.Datafields, df)
+			}
+		}
+		l = append(l, rec)
+	}
+
+	return l, nil
+}
+
+func httpError(w http.ResponseWriter, status int, err error) {
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+
+	b, _ := json.Marshal(map[string]string{"error": err.Error()})
+	_, _ = w.Write(b)
+}
+
+var formTemplate = template.Must(template.New("form").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>MARC record decoder</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+textarea { width: 100%; height: 20em; font-family: monospace; }
+</style>
+</head>
+<body>
+<h1>MARC record decoder</h1>
+<p>Paste a binary MARC, MARCXML or MARC-in-JSON record, or choose a file,
+to see the decoded leader, control fields and data fields as JSON.</p>
+<form method="post" action="/decode" enctype="multipart/form-data">
+<p><textarea name="record"></textarea></p>
+<p><input type="file" name="file"></p>
+<p><input type="submit" value="Decode"></p>
+</form>
+<p>Scripts may POST the record data directly to <code>/decode</code>.</p>
+</body>
+</html>
+`))
+
+func showHelp() {
+	fmt.Println(os.Args[0])
+	fmt.Println("   Runs a local HTTP service that decodes MARC records.")
+	fmt.Printf("    Usage: %s [-addr <host:port>]\n", os.Args[0])
+	fmt.Println()
+	fmt.Println("    POST binary MARC, MARCXML or MARC-in-JSON record(s) to /decode, either as the request body or")
+	fmt.Println("    as the \"record\" field or \"file\" upload of a form. The response is a JSON array with, for each")
+	fmt.Println("    record, the decoded leader and control fields (as for dumprec -format json), a summary, the")
+	fmt.Println("    data fields and any validation issues. The form at / may be used to paste a record.")
+	fmt.Println()
+	fmt.Println("    The default address only accepts connections from the local machine.")
+	fmt.Println()
+	os.Exit(0)
+}
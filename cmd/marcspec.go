@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	//
+	"github.com/gsiems/go-marc21-details/pkg/details"
+)
+
+func main() {
+
+	var format, outfile string
+
+	flag.StringVar(&format, "format", "json", "The output format (json or yaml).")
+	flag.StringVar(&outfile, "o", "", "The file to write to (defaults to stdout).")
+	flag.Usage = showHelp
+	flag.Parse()
+
+	if flag.NArg() > 0 || (format != "json" && format != "yaml") {
+		showHelp()
+	}
+
+	out := os.Stdout
+	if outfile != "" {
+		fo, err := os.Create(outfile)
+		if err != nil {
+			log.Fatal(fmt.Printf("File create failed: %q", err))
+		}
+		defer func() {
+			if cerr := fo.Close(); cerr != nil {
+				log.Fatal(cerr)
+			}
+		}()
+		out = fo
+	}
+
+	w := bufio.NewWriter(out)
+	spec := details.Specification()
+
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		err := enc.Encode(spec)
+		if err != nil {
+			log.Fatal(err)
+		}
+	case "yaml":
+		writeYAML(w, spec)
+	}
+
+	err := w.Flush()
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// writeYAML writes the specification as YAML. The keys are the same as
+// for the JSON output and all strings are double quoted so that blank
+// codes are preserved.
+func writeYAML(w io.Writer, spec details.Spec) {
+
+	q := strconv.Quote
+
+	fmt.Fprintln(w, "formats:")
+	for _, f := range spec.Formats {
+		fmt.Fprintf(w, "  - name: %s\n", q(f.Name))
+		fmt.Fprintln(w, "    fields:")
+		for _, fs := range f.Fields {
+			fmt.Fprintf(w, "      - tag: %s\n", q(fs.Tag))
+			fmt.Fprintf(w, "        code: %s\n", q(fs.Code))
+			if fs.Material != "" {
+				fmt.Fprintf(w, "        material: %s\n", q(fs.Material))
+			}
+			fmt.Fprintf(w, "        name: %s\n", q(fs.Name))
+			fmt.Fprintf(w, "        length: %d\n", fs.Length)
+			fmt.Fprintln(w, "        elements:")
+			for _, e := range fs.Elements {
+				fmt.Fprintf(w, "          - key: %s\n", q(e.Key))
+				fmt.Fprintf(w, "            id: %s\n", q(e.ID))
+				fmt.Fprintf(w, "            name: %s\n", q(e.Name))
+				fmt.Fprintf(w, "            positions: %s\n", q(e.Positions))
+				fmt.Fprintf(w, "            offset: %d\n", e.Offset)
+				fmt.Fprintf(w, "            width: %d\n", e.Width)
+				fmt.Fprintf(w, "            code_width: %d\n", e.CodeWidth)
+				fmt.Fprintf(w, "            type: %s\n", q(e.Type))
+				fmt.Fprintf(w, "            multi_code: %t\n", e.MultiCode)
+				if e.RangeLabel != "" {
+					fmt.Fprintf(w, "            range_label: %s\n", q(e.RangeLabel))
+				}
+				if len(e.Codes) == 0 {
+					continue
+				}
+				fmt.Fprintln(w, "            codes:")
+				for _, c := range e.Codes {
+					fmt.Fprintf(w, "              - code: %s\n", q(c.Code))
+					fmt.Fprintf(w, "                label: %s\n", q(c.Label))
+					if c.Obsolete {
+						fmt.Fprintln(w, "                obsolete: true")
+					}
+				}
+			}
+		}
+	}
+}
+
+func showHelp() {
+	fmt.Println(os.Args[0])
+	fmt.Println("   Writes the leader, 006, 007 and 008 definitions for all record formats and material types.")
+	fmt.Printf("    Usage: %s [-format json|yaml] [-o <output file>]\n", os.Args[0])
+	fmt.Println()
+	fmt.Println("    The output is described by cmd/marcspec.schema.json. Blanks in codes are written as is.")
+	fmt.Println("    Obsolete fields, elements and codes are not included as the generated definitions do not")
+	fmt.Println("    currently contain them.")
+	fmt.Println()
+	os.Exit(0)
+}
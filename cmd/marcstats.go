@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	//
+	"github.com/gsiems/go-marc21-details/pkg/details"
+)
+
+func main() {
+
+	var format string
+
+	flag.StringVar(&format, "format", "text", "The output format (text, json or html).")
+	flag.Usage = showHelp
+	flag.Parse()
+
+	if flag.NArg() == 0 || (format != "text" && format != "json" && format != "html") {
+		showHelp()
+	}
+
+	p := details.NewProfile()
+	for _, marcfile := range flag.Args() {
+		profileFile(p, marcfile)
+	}
+
+	switch format {
+	case "json":
+		b, err := json.MarshalIndent(struct {
+			Records int                    `json:"records"`
+			Groups  []details.ProfileGroup `json:"groups"`
+		}{p.Records(), p.Groups()}, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(string(b))
+	case "html":
+		fmt.Print(details.HTMLReportHeader)
+		fmt.Print(p.AsHTML())
+		fmt.Print(details.HTMLReportFooter)
+	default:
+		fmt.Print(p.AsText())
+	}
+}
+
+// profileFile adds the records of a MARC file to the profile
+func profileFile(p *details.Profile, marcfile string) {
+
+	fi, err := os.Open(marcfile)
+	if err != nil {
+		log.Fatal(fmt.Printf("File open failed: %q", err))
+	}
+	defer func() {
+		if cerr := fi.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	rr := details.NewRecordReader(fi)
+
+	for {
+		rec, err := rr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		p.Add(*rec)
+	}
+}
+
+func showHelp() {
+	fmt.Println(os.Args[0])
+	fmt.Println("   Profiles the leader, 006, 007 and 008 elements of the records in one or more MARC (ISO 2709 or MARCXML) files.")
+	fmt.Printf("    Usage: %s [-format text|json|html] <MARC file to profile> [<MARC file> ...]\n", os.Args[0])
+	fmt.Println()
+	fmt.Println("    For each record format and material type, reports the number and length anomalies of")
+	fmt.Println("    the fields, and for each element the frequencies of the codes found and the counts of")
+	fmt.Println("    blank, fill, undefined, obsolete and missing values. Blanks that are not a code of the")
+	fmt.Println("    element are also counted as undefined. The definitions do not currently have any obsolete")
+	fmt.Println("    codes so these are counted as undefined.")
+	fmt.Println()
+	os.Exit(0)
+}
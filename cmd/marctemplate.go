@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	//
+	"github.com/gsiems/go-marc21-details/pkg/details"
+	"github.com/gsiems/go-marc21/pkg/marc21"
+)
+
+// valueList contains the element values given with -set
+type valueList map[string]string
+
+func (v valueList) String() string {
+	return fmt.Sprint(map[string]string(v))
+}
+
+func (v valueList) Set(s string) error {
+	i := strings.Index(s, "=")
+	if i < 1 {
+		return fmt.Errorf("expected <element key>=<value>, got %q", s)
+	}
+	v[strings.TrimSpace(s[:i])] = strings.TrimSpace(s[i+1:])
+	return nil
+}
+
+func main() {
+
+	var source, language, country, forms, categories, format string
+	values := make(valueList)
+
+	flag.StringVar(&source, "source", "", "The cataloging source code (008/39).")
+	flag.StringVar(&language, "lang", "", "The language code.")
+	flag.StringVar(&country, "country", "", "The country code (bibliography 008/15-17).")
+	flag.StringVar(&forms, "006", "", "The 006 forms of material to include (bibliography only, e.g. \"m\" or \"ms\").")
+	flag.StringVar(&categories, "007", "", "The 007 categories of material to include (e.g. \"c\" or \"cv\").")
+	flag.StringVar(&format, "format", "text", "The output format (text or json).")
+	flag.Var(values, "set", "Set an element, as <element key>=<value> (may be repeated).")
+	flag.Usage = showHelp
+	flag.Parse()
+
+	if flag.NArg() != 1 || (format != "text" && format != "json") {
+		showHelp()
+	}
+
+	recFormat, material, ok := templateType(flag.Arg(0))
+	if !ok {
+		showHelp()
+	}
+
+	d := details.TemplateDefaults{
+		CatalogingSource: source,
+		Language:         language,
+		Country:          country,
+		Values:           values,
+	}
+
+	t, err := details.NewTemplate(recFormat, material, d)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fields := [][2]string{{"LDR", t.Leader}}
+
+	for _, c := range forms {
+		s, err := details.Template006(string(c), d)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fields = append(fields, [2]string{"006", s})
+	}
+
+	for _, c := range categories {
+		s, err := details.Template007(recFormat, string(c), d)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fields = append(fields, [2]string{"007", s})
+	}
+
+	fields = append(fields, [2]string{"008", t.Cf008})
+
+	if format == "json" {
+		var l []map[string]string
+		for _, f := range fields {
+			l = append(l, map[string]string{"tag": f[0], "text": f[1]})
+		}
+		b, err := json.MarshalIndent(map[string]interface{}{"format": t.Format, "material": t.Material, "name": t.Name, "fields": l}, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(string(b))
+		return
+	}
+
+	fmt.Printf("# %s\n", t.Name)
+	for _, f := range fields {
+		fmt.Printf("%s %s\n", f[0], strings.Replace(f[1], " ", "#", -1))
+	}
+}
+
+// templateType returns the record format and material type for a
+// template name
+func templateType(name string) (format int, material string, ok bool) {
+
+	switch strings.ToLower(name) {
+	case "bk", "cf", "mp", "mu", "cr", "vm", "mx":
+		return marc21.Bibliography, strings.ToUpper(name), true
+	case "holdings":
+		return marc21.Holdings, "", true
+	case "authority":
+		return marc21.Authority, "", true
+	}
+
+	return 0, "", false
+}
+
+func showHelp() {
+	fmt.Println(os.Args[0])
+	fmt.Println("   Writes the default leader and control field values for a new record.")
+	fmt.Printf("    Usage: %s [-source <code>] [-lang <code>] [-country <code>] [-006 <forms>] [-007 <categories>]\n", os.Args[0])
+	fmt.Println("           [-set <element key>=<value> ...] [-format text|json] <template>")
+	fmt.Println()
+	fmt.Println("    The template is one of bk, cf, mp, mu, cr, vm, mx (bibliography material types), holdings or")
+	fmt.Println("    authority. In text output blanks are shown as #. Element keys are as for dumprec -format json,")
+	fmt.Println("    the material may be omitted. For example:")
+	fmt.Println()
+	fmt.Printf("      %s -source d -lang eng -country xxu -007 c -set 008.form_of_item=o bk\n", os.Args[0])
+	fmt.Println()
+	os.Exit(0)
+}
@@ -0,0 +1,259 @@
+// Copyright 2017-2018 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package details
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gsiems/go-marc21/pkg/marc21"
+)
+
+/*
+Change reports summarize the differences between two sets of records,
+for example an authority or vendor file before and after a reload. The
+caller matches the records (by 001/003, 035, etc.) and adds them to the
+report as added, deleted or compared records. The element changes of
+the compared records (see DiffRecords) are counted by the number of
+records having each change:
+
+	1204 records changed 008/39 Cataloging source: blank -> d Other
+
+Added and removed 006 and 007 fields are counted by field and material,
+as are changes to the control fields that have no element definitions
+(001, 003, 005, etc.).
+*/
+
+// ChangeReport gathers the differences between two sets of records
+type ChangeReport struct {
+	oldRecords int
+	newRecords int
+	added      []string
+	deleted    []string
+	changed    []string
+	unchanged  int
+	counts     map[string]*ChangeCount
+}
+
+// ChangeSummary contains the differences between two sets of records.
+// Added, Deleted and Changed are the identifiers of the records, as
+// given by the caller, in the order that they were added to the report.
+type ChangeSummary struct {
+	OldRecords int           `json:"old_records"`
+	NewRecords int           `json:"new_records"`
+	Added      []string      `json:"added"`
+	Deleted    []string      `json:"deleted"`
+	Changed    []string      `json:"changed"`
+	Unchanged  int           `json:"unchanged"`
+	Changes    []ChangeCount `json:"changes"`
+}
+
+// ChangeCount is the number of records having a change. Key, Name,
+// Positions and the codes are empty for changes to whole fields (added
+// or removed 006 and 007 fields and changes to the control fields that
+// have no element definitions).
+type ChangeCount struct {
+	Tag       string `json:"tag"`
+	Material  string `json:"material,omitempty"`
+	Change    string `json:"change"`
+	Key       string `json:"key,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Positions string `json:"positions,omitempty"`
+	Seq       int    `json:"seq,omitempty"`
+	OldCode   string `json:"old_code,omitempty"`
+	OldLabel  string `json:"old_label,omitempty"`
+	NewCode   string `json:"new_code,omitempty"`
+	NewLabel  string `json:"new_label,omitempty"`
+	Records   int    `json:"records"`
+	offset    int
+}
+
+// NewChangeReport returns an empty change report
+func NewChangeReport() *ChangeReport {
+	return &ChangeReport{counts: make(map[string]*ChangeCount)}
+}
+
+// Added adds a record that is only in the new set of records
+func (r *ChangeReport) Added(id string) {
+	r.newRecords++
+	r.added = append(r.added, id)
+}
+
+// Deleted adds a record that is only in the old set of records
+func (r *ChangeReport) Deleted(id string) {
+	r.oldRecords++
+	r.deleted = append(r.deleted, id)
+}
+
+// Compare compares the old and new versions of a record and adds the
+// differences to the report
+func (r *ChangeReport) Compare(id string, oldRec, newRec marc21.Record) RecordDiff {
+
+	r.oldRecords++
+	r.newRecords++
+
+	d := DiffRecords(oldRec, newRec)
+	if d.Equal() {
+		r.unchanged++
+		return d
+	}
+	r.changed = append(r.changed, id)
+
+	// Each change is counted once per record
+	seen := make(map[string]bool)
+	count := func(k string, c ChangeCount) {
+		if seen[k] {
+			return
+		}
+		seen[k] = true
+		if _, ok := r.counts[k]; !ok {
+			r.counts[k] = &c
+		}
+		r.counts[k].Records++
+	}
+
+	for _, fd := range d.Fields {
+		material := fd.NewMaterial
+		if fd.Change == ChangeRemoved {
+			material = fd.OldMaterial
+		}
+
+		if fd.Change != ChangeModified || len(fd.Elements) == 0 {
+			k := strings.Join([]string{fd.Tag, material, fd.Change}, "|")
+			count(k, ChangeCount{Tag: fd.Tag, Material: material, Change: fd.Change, offset: -1})
+			continue
+		}
+
+		for _, c := range fd.Elements {
+			k := strings.Join([]string{fd.Tag, c.Key, strconv.Itoa(c.Seq), c.Change, c.OldCode, c.NewCode}, "|")
+			count(k, ChangeCount{
+				Tag:       fd.Tag,
+				Material:  material,
+				Change:    c.Change,
+				Key:       c.Key,
+				Name:      c.Name,
+				Positions: c.Positions,
+				Seq:       c.Seq,
+				OldCode:   c.OldCode,
+				OldLabel:  c.OldLabel,
+				NewCode:   c.NewCode,
+				NewLabel:  c.NewLabel,
+				offset:    c.offset,
+			})
+		}
+	}
+
+	return d
+}
+
+// Summary returns the differences. The changes are in descending order
+// of the number of records, then in field and position order.
+func (r *ChangeReport) Summary() (s ChangeSummary) {
+
+	s = ChangeSummary{
+		OldRecords: r.oldRecords,
+		NewRecords: r.newRecords,
+		Added:      r.added,
+		Deleted:    r.deleted,
+		Changed:    r.changed,
+		Unchanged:  r.unchanged,
+	}
+
+	for _, c := range r.counts {
+		s.Changes = append(s.Changes, *c)
+	}
+	sort.Slice(s.Changes, func(i, j int) bool {
+		a, b := s.Changes[i], s.Changes[j]
+		switch {
+		case a.Records != b.Records:
+			return a.Records > b.Records
+		case tagRank(a.Tag) != tagRank(b.Tag):
+			return tagRank(a.Tag) < tagRank(b.Tag)
+		case a.Tag != b.Tag:
+			return a.Tag < b.Tag
+		case a.offset != b.offset:
+			return a.offset < b.offset
+		}
+		return a.String() < b.String()
+	})
+
+	return s
+}
+
+// String returns the change as text, for example
+// "changed 008/39 Cataloging source: blank -> d Other"
+func (c ChangeCount) String() string {
+
+	field := c.Tag
+	if c.Material != "" {
+		field += " (" + c.Material + ")"
+	}
+
+	if c.Key == "" {
+		return c.Change + " " + field
+	}
+
+	name := c.Name
+	if c.Seq > 0 {
+		name += " " + strconv.Itoa(c.Seq)
+	}
+	element := c.Tag + "/" + c.Positions + " " + name
+
+	switch c.Change {
+	case ChangeAdded:
+		return "added " + element + ": " + CodeText(c.NewCode, c.NewLabel)
+	case ChangeRemoved:
+		return "removed " + element + ": " + CodeText(c.OldCode, c.OldLabel)
+	}
+
+	return "changed " + element + ": " + CodeText(c.OldCode, c.OldLabel) + " -> " + CodeText(c.NewCode, c.NewLabel)
+}
+
+// AsText returns the differences as a plain text report. The record
+// identifiers are only listed if list is true.
+func (r *ChangeReport) AsText(list bool) string {
+
+	s := r.Summary()
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Old records: %d\n", s.OldRecords)
+	fmt.Fprintf(&b, "New records: %d\n", s.NewRecords)
+	fmt.Fprintf(&b, "Added:       %d\n", len(s.Added))
+	fmt.Fprintf(&b, "Deleted:     %d\n", len(s.Deleted))
+	fmt.Fprintf(&b, "Changed:     %d\n", len(s.Changed))
+	fmt.Fprintf(&b, "Unchanged:   %d\n", s.Unchanged)
+
+	if len(s.Changes) > 0 {
+		b.WriteString("\nChanges:\n")
+		for _, c := range s.Changes {
+			fmt.Fprintf(&b, "  %8d records %s\n", c.Records, c)
+		}
+	}
+
+	if !list {
+		return b.String()
+	}
+
+	for _, g := range []struct {
+		name string
+		ids  []string
+	}{
+		{"Added", s.Added},
+		{"Deleted", s.Deleted},
+		{"Changed", s.Changed},
+	} {
+		if len(g.ids) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n%s records:\n", g.name)
+		for _, id := range g.ids {
+			b.WriteString("  " + id + "\n")
+		}
+	}
+
+	return b.String()
+}
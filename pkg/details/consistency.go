@@ -0,0 +1,176 @@
+// Copyright 2017-2018 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package details
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gsiems/go-marc21/pkg/marc21"
+)
+
+/*
+Consistency checks between the leader/06-07 (type of record and
+bibliographic level) and the 006 and 007 fields of bibliography
+records.
+*/
+
+// consistencyRules is the list of leader/006/007 consistency rules
+var consistencyRules = []Rule{
+	{
+		ID:          "007-category",
+		Description: "The 007 category of material should fit the leader/06 type of record",
+		Severity:    SeverityWarning,
+		check:       check007Category,
+	},
+	{
+		ID:          "006-form",
+		Description: "The 006 form of material should be for a different material type than the leader/06-07",
+		Severity:    SeverityWarning,
+		check:       check006Form,
+	},
+	{
+		ID:          "007-duplicate",
+		Description: "007 fields for the same category of material should not be duplicated",
+		Severity:    SeverityWarning,
+		check:       check007Duplicates,
+	},
+	{
+		ID:          "007-length",
+		Description: "The 007 must have the length defined for its category of material",
+		Severity:    SeverityError,
+		check:       check007Length,
+	},
+}
+
+// typeOfRecord007Categories lists, for each leader/06 type of record,
+// the 007 categories of material that plausibly describe the item.
+// Electronic resource, microform, tactile and unspecified 007s
+// (reproductions and alternate formats) fit any type of record, as
+// does anything for kits and mixed materials.
+var typeOfRecord007Categories = map[string]string{
+	"a": "t",
+	"t": "t",
+	"c": "qt",
+	"d": "qt",
+	"e": "adr",
+	"f": "adr",
+	"g": "gmv",
+	"i": "s",
+	"j": "s",
+	"k": "kr",
+	"m": "",
+	"r": "",
+}
+
+const any007Categories = "chfz"
+
+func check007Category(rec marc21.Record, rd RecordDesc) (l []Issue) {
+
+	if rec.RecordFormat() != marc21.Bibliography {
+		return l
+	}
+
+	tr := pluckByte(rec.Leader.Text, 6)
+	cats, ok := typeOfRecord007Categories[tr]
+	if !ok {
+		// Kits, mixed materials and invalid types of record
+		return l
+	}
+	cats += any007Categories
+
+	for _, cf := range rec.GetControlfields("007") {
+		cat := pluckByte(cf.Text, 0)
+		if _, defined := bibliography007CategoryOfMaterial[cat]; !defined || strings.Contains(cats, cat) {
+			continue
+		}
+		l = append(l, Issue{
+			Tag:       "007",
+			Positions: "00",
+			Message: fmt.Sprintf("Category of material %s does not fit Leader/06 Type of record %s",
+				quoteCode(cat, bibliography007CategoryOfMaterial[cat]), quoteCode(tr, bibliographyLdrTypeOfRecord[tr])),
+		})
+	}
+
+	return l
+}
+
+func check006Form(rec marc21.Record, rd RecordDesc) (l []Issue) {
+
+	if rec.RecordFormat() != marc21.Bibliography || rd.Material == "" {
+		return l
+	}
+
+	for _, cf := range rec.GetControlfields("006") {
+		form := pluckByte(cf.Text, 0)
+		material, ok := bibliography006Materials[form]
+		if !ok || material != rd.Material {
+			continue
+		}
+		l = append(l, Issue{
+			Tag:       "006",
+			Positions: "00",
+			Message: fmt.Sprintf("Form of material %s is for the same material type (%s) as Leader/06-07 %s; the 008 already describes it",
+				quoteCode(form, bibliography006FormOfMaterial[form]), material, quoteCode(pluckBytes(rec.Leader.Text, 6, 2), bibliographyMaterials[material].name)),
+		})
+	}
+
+	return l
+}
+
+func check007Duplicates(rec marc21.Record, rd RecordDesc) (l []Issue) {
+
+	format := rec.RecordFormat()
+
+	// Note that GetControlfields removes duplicates
+	seen := make(map[string]bool)
+	for _, cf := range rec.Controlfields {
+		if cf.Tag != "007" || cf.Text == "" {
+			continue
+		}
+		cat := pluckByte(cf.Text, 0)
+		if !seen[cat] {
+			seen[cat] = true
+			continue
+		}
+		l = append(l, Issue{
+			Tag: "007",
+			Message: fmt.Sprintf("more than one 007 for Category of material %s: %q",
+				quoteCode(cat, categoryOfMaterialLabel(format, cat)), cf.Text),
+		})
+	}
+
+	return l
+}
+
+func check007Length(rec marc21.Record, rd RecordDesc) (l []Issue) {
+
+	format := rec.RecordFormat()
+
+	for _, cf := range rec.GetControlfields("007") {
+		cat := pluckByte(cf.Text, 0)
+		fd, ok := Cf007Def(format, cat)
+		if !ok || len(cf.Text) == fd.Length() {
+			continue
+		}
+		l = append(l, Issue{
+			Tag: "007",
+			Message: fmt.Sprintf("length is %d, expected %d for Category of material %s",
+				len(cf.Text), fd.Length(), quoteCode(cat, categoryOfMaterialLabel(format, cat))),
+		})
+	}
+
+	return l
+}
+
+// categoryOfMaterialLabel returns the label of a 007 category of
+// material code for a record format
+func categoryOfMaterialLabel(format int, cat string) string {
+	fd, ok := Cf007Def(format, cat)
+	if !ok || len(fd.Elements) == 0 {
+		return ""
+	}
+	return fd.Elements[0].Codes[cat]
+}
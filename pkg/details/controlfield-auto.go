@@ -204,6 +204,34 @@ func parseAuthority008(d *Cf008Desc, s string) {
 	d.append("(39/01) Cataloging source", CodeValue{Code: c, Label: l, Offset: 39, Width: 1})
 }
 
+// authority008Elements defines the elements that are parsed by
+// parseAuthority008
+var authority008Elements = []ElementDef{
+	{Name: "Date entered on file", Offset: 0, Width: 6, CodeWidth: 6, FnType: "read"},
+	{Name: "Direct or indirect geographic subdivision", Offset: 6, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: authority008DirectOrIndirectGeographicSubdivision},
+	{Name: "Romanization scheme", Offset: 7, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: authority008RomanizationScheme},
+	{Name: "Language of catalog", Offset: 8, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: authority008LanguageOfCatalog},
+	{Name: "Kind of record", Offset: 9, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: authority008KindOfRecord},
+	{Name: "Descriptive cataloging rules", Offset: 10, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: authority008DescriptiveCatalogingRules},
+	{Name: "Subject heading system/thesaurus", Offset: 11, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: authority008SubjectHeadingSystemThesaurus},
+	{Name: "Type of series", Offset: 12, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: authority008TypeOfSeries},
+	{Name: "Numbered or unnumbered series", Offset: 13, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: authority008NumberedOrUnnumberedSeries},
+	{Name: "Heading use--main or added entry", Offset: 14, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: authority008HeadingUseMainOrAddedEntry},
+	{Name: "Heading use--subject added entry", Offset: 15, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: authority008HeadingUseSubjectAddedEntry},
+	{Name: "Heading use--series added entry", Offset: 16, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: authority008HeadingUseSeriesAddedEntry},
+	{Name: "Type of subject subdivision", Offset: 17, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: authority008TypeOfSubjectSubdivision},
+	{Name: "Undefined character positions", Offset: 18, Width: 10, CodeWidth: 10, FnType: "read"},
+	{Name: "Type of government agency", Offset: 28, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: authority008TypeOfGovernmentAgency},
+	{Name: "Reference evaluation", Offset: 29, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: authority008ReferenceEvaluation},
+	{Name: "Undefined character position", Offset: 30, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Record update in process", Offset: 31, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: authority008RecordUpdateInProcess},
+	{Name: "Undifferentiated personal name", Offset: 32, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: authority008UndifferentiatedPersonalName},
+	{Name: "Level of establishment", Offset: 33, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: authority008LevelOfEstablishment},
+	{Name: "Undefined character positions", Offset: 34, Width: 4, CodeWidth: 4, FnType: "read"},
+	{Name: "Modified record", Offset: 38, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: authority008ModifiedRecord},
+	{Name: "Cataloging source", Offset: 39, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: authority008CatalogingSource},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Bibliography
 var bibliography006FormOfMaterial = map[string]string{
@@ -339,6 +367,19 @@ func parseBibliography007MAP(s string) (pd Cf007Desc) {
 	return pd
 }
 
+// bibliography007MAPElements defines the elements that are parsed by
+// parseBibliography007MAP
+var bibliography007MAPElements = []ElementDef{
+	{Name: "Category of material", Offset: 0, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007CategoryOfMaterial},
+	{Name: "Specific material designation", Offset: 1, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007MAPSpecificMaterialDesignation},
+	{Name: "Undefined", Offset: 2, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Color", Offset: 3, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007MAPColor},
+	{Name: "Physical medium", Offset: 4, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007MAPPhysicalMedium},
+	{Name: "Type of reproduction", Offset: 5, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007MAPTypeOfReproduction},
+	{Name: "Production/reproduction details", Offset: 6, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007MAPProductionReproductionDetails},
+	{Name: "Positive/negative aspect", Offset: 7, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007MAPPositiveNegativeAspect},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Bibliography -- 007 -- ELECTRONIC RESOURCE
 var bibliography007ELRSpecificMaterialDesignation = map[string]string{
@@ -476,6 +517,23 @@ func parseBibliography007ELR(s string) (pd Cf007Desc) {
 	return pd
 }
 
+// bibliography007ELRElements defines the elements that are parsed by
+// parseBibliography007ELR
+var bibliography007ELRElements = []ElementDef{
+	{Name: "Category of material", Offset: 0, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007CategoryOfMaterial},
+	{Name: "Specific material designation", Offset: 1, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007ELRSpecificMaterialDesignation},
+	{Name: "Undefined", Offset: 2, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Color", Offset: 3, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007ELRColor},
+	{Name: "Dimensions", Offset: 4, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007ELRDimensions},
+	{Name: "Sound", Offset: 5, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007ELRSound},
+	{Name: "Image bit depth", Offset: 6, Width: 3, CodeWidth: 3, FnType: "hybrid", Codes: bibliography007ELRImageBitDepth, RangeLabel: "Exact bit depth"},
+	{Name: "File formats", Offset: 9, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007ELRFileFormats},
+	{Name: "Quality assurance target(s)", Offset: 10, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007ELRQualityAssuranceTargetS},
+	{Name: "Antecedent/source", Offset: 11, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007ELRAntecedentSource},
+	{Name: "Level of compression", Offset: 12, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007ELRLevelOfCompression},
+	{Name: "Reformatting quality", Offset: 13, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007ELRReformattingQuality},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Bibliography -- 007 -- GLOBE
 var bibliography007GLBSpecificMaterialDesignation = map[string]string{
@@ -541,6 +599,17 @@ func parseBibliography007GLB(s string) (pd Cf007Desc) {
 	return pd
 }
 
+// bibliography007GLBElements defines the elements that are parsed by
+// parseBibliography007GLB
+var bibliography007GLBElements = []ElementDef{
+	{Name: "Category of material", Offset: 0, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007CategoryOfMaterial},
+	{Name: "Specific material designation", Offset: 1, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007GLBSpecificMaterialDesignation},
+	{Name: "Undefined", Offset: 2, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Color", Offset: 3, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007GLBColor},
+	{Name: "Physical medium", Offset: 4, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007GLBPhysicalMedium},
+	{Name: "Type of reproduction", Offset: 5, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007GLBTypeOfReproduction},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Bibliography -- 007 -- TACTILE MATERIAL
 var bibliography007TAMSpecificMaterialDesignation = map[string]string{
@@ -637,6 +706,18 @@ func parseBibliography007TAM(s string) (pd Cf007Desc) {
 	return pd
 }
 
+// bibliography007TAMElements defines the elements that are parsed by
+// parseBibliography007TAM
+var bibliography007TAMElements = []ElementDef{
+	{Name: "Category of material", Offset: 0, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007CategoryOfMaterial},
+	{Name: "Specific material designation", Offset: 1, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007TAMSpecificMaterialDesignation},
+	{Name: "Undefined", Offset: 2, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Class of braille writing", Offset: 3, Width: 2, CodeWidth: 1, FnType: "multi", Codes: bibliography007TAMClassOfBrailleWriting},
+	{Name: "Level of contraction", Offset: 5, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007TAMLevelOfContraction},
+	{Name: "Braille music format", Offset: 6, Width: 3, CodeWidth: 1, FnType: "multi", Codes: bibliography007TAMBrailleMusicFormat},
+	{Name: "Specific physical characteristics", Offset: 9, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007TAMSpecificPhysicalCharacteristics},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Bibliography -- 007 -- PROJECTED GRAPHIC
 var bibliography007PRGSpecificMaterialDesignation = map[string]string{
@@ -757,6 +838,20 @@ func parseBibliography007PRG(s string) (pd Cf007Desc) {
 	return pd
 }
 
+// bibliography007PRGElements defines the elements that are parsed by
+// parseBibliography007PRG
+var bibliography007PRGElements = []ElementDef{
+	{Name: "Category of material", Offset: 0, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007CategoryOfMaterial},
+	{Name: "Specific material designation", Offset: 1, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007PRGSpecificMaterialDesignation},
+	{Name: "Undefined", Offset: 2, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Color", Offset: 3, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007PRGColor},
+	{Name: "Base of emulsion", Offset: 4, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007PRGBaseOfEmulsion},
+	{Name: "Sound on medium or separate", Offset: 5, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007PRGSoundOnMediumOrSeparate},
+	{Name: "Medium for sound", Offset: 6, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007PRGMediumForSound},
+	{Name: "Dimensions", Offset: 7, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007PRGDimensions},
+	{Name: "Secondary support material", Offset: 8, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007PRGSecondarySupportMaterial},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Bibliography -- 007 -- MICROFORM
 var bibliography007MICSpecificMaterialDesignation = map[string]string{
@@ -877,6 +972,22 @@ func parseBibliography007MIC(s string) (pd Cf007Desc) {
 	return pd
 }
 
+// bibliography007MICElements defines the elements that are parsed by
+// parseBibliography007MIC
+var bibliography007MICElements = []ElementDef{
+	{Name: "Category of material", Offset: 0, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007CategoryOfMaterial},
+	{Name: "Specific material designation", Offset: 1, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007MICSpecificMaterialDesignation},
+	{Name: "Undefined", Offset: 2, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Positive/negative aspect", Offset: 3, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007MICPositiveNegativeAspect},
+	{Name: "Dimensions", Offset: 4, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007MICDimensions},
+	{Name: "Reduction ratio range", Offset: 5, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007MICReductionRatioRange},
+	{Name: "Reduction ratio", Offset: 6, Width: 3, CodeWidth: 3, FnType: "read"},
+	{Name: "Color", Offset: 9, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007MICColor},
+	{Name: "Emulsion on film", Offset: 10, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007MICEmulsionOnFilm},
+	{Name: "Generation", Offset: 11, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007MICGeneration},
+	{Name: "Base of film", Offset: 12, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007MICBaseOfFilm},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Bibliography -- 007 -- NONPROJECTED GRAPHIC
 var bibliography007NPGSpecificMaterialDesignation = map[string]string{
@@ -987,6 +1098,17 @@ func parseBibliography007NPG(s string) (pd Cf007Desc) {
 	return pd
 }
 
+// bibliography007NPGElements defines the elements that are parsed by
+// parseBibliography007NPG
+var bibliography007NPGElements = []ElementDef{
+	{Name: "Category of material", Offset: 0, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007CategoryOfMaterial},
+	{Name: "Specific material designation", Offset: 1, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007NPGSpecificMaterialDesignation},
+	{Name: "Undefined", Offset: 2, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Color", Offset: 3, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007NPGColor},
+	{Name: "Primary support material", Offset: 4, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007NPGPrimarySupportMaterial},
+	{Name: "Secondary support material", Offset: 5, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007NPGSecondarySupportMaterial},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Bibliography -- 007 -- MOTION PICTURE
 var bibliography007MOPSpecificMaterialDesignation = map[string]string{
@@ -1209,6 +1331,29 @@ func parseBibliography007MOP(s string) (pd Cf007Desc) {
 	return pd
 }
 
+// bibliography007MOPElements defines the elements that are parsed by
+// parseBibliography007MOP
+var bibliography007MOPElements = []ElementDef{
+	{Name: "Category of material", Offset: 0, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007CategoryOfMaterial},
+	{Name: "Specific material designation", Offset: 1, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007MOPSpecificMaterialDesignation},
+	{Name: "Undefined", Offset: 2, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Color", Offset: 3, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007MOPColor},
+	{Name: "Motion picture presentation format", Offset: 4, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007MOPMotionPicturePresentationFormat},
+	{Name: "Sound on medium or separate", Offset: 5, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007MOPSoundOnMediumOrSeparate},
+	{Name: "Medium for sound", Offset: 6, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007MOPMediumForSound},
+	{Name: "Dimensions", Offset: 7, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007MOPDimensions},
+	{Name: "Configuration of playback channels", Offset: 8, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007MOPConfigurationOfPlaybackChannels},
+	{Name: "Production elements", Offset: 9, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007MOPProductionElements},
+	{Name: "Positive/negative aspect", Offset: 10, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007MOPPositiveNegativeAspect},
+	{Name: "Generation", Offset: 11, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007MOPGeneration},
+	{Name: "Base of film", Offset: 12, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007MOPBaseOfFilm},
+	{Name: "Refined categories of color", Offset: 13, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007MOPRefinedCategoriesOfColor},
+	{Name: "Kind of color stock or print", Offset: 14, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007MOPKindOfColorStockOrPrint},
+	{Name: "Deterioration stage", Offset: 15, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007MOPDeteriorationStage},
+	{Name: "Completeness", Offset: 16, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007MOPCompleteness},
+	{Name: "Film inspection date", Offset: 17, Width: 6, CodeWidth: 6, FnType: "read"},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Bibliography -- 007 -- KIT
 var bibliography007KITSpecificMaterialDesignation = map[string]string{
@@ -1232,6 +1377,13 @@ func parseBibliography007KIT(s string) (pd Cf007Desc) {
 	return pd
 }
 
+// bibliography007KITElements defines the elements that are parsed by
+// parseBibliography007KIT
+var bibliography007KITElements = []ElementDef{
+	{Name: "Category of material", Offset: 0, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007CategoryOfMaterial},
+	{Name: "Specific material designation", Offset: 1, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007KITSpecificMaterialDesignation},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Bibliography -- 007 -- NOTATED MUSIC
 var bibliography007NMUSpecificMaterialDesignation = map[string]string{
@@ -1255,6 +1407,13 @@ func parseBibliography007NMU(s string) (pd Cf007Desc) {
 	return pd
 }
 
+// bibliography007NMUElements defines the elements that are parsed by
+// parseBibliography007NMU
+var bibliography007NMUElements = []ElementDef{
+	{Name: "Category of material", Offset: 0, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007CategoryOfMaterial},
+	{Name: "Specific material designation", Offset: 1, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007NMUSpecificMaterialDesignation},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Bibliography -- 007 -- REMOTE-SENSING IMAGE
 var bibliography007RSISpecificMaterialDesignation = map[string]string{
@@ -1401,6 +1560,21 @@ func parseBibliography007RSI(s string) (pd Cf007Desc) {
 	return pd
 }
 
+// bibliography007RSIElements defines the elements that are parsed by
+// parseBibliography007RSI
+var bibliography007RSIElements = []ElementDef{
+	{Name: "Category of material", Offset: 0, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007CategoryOfMaterial},
+	{Name: "Specific material designation", Offset: 1, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007RSISpecificMaterialDesignation},
+	{Name: "Undefined", Offset: 2, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Altitude of sensor", Offset: 3, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007RSIAltitudeOfSensor},
+	{Name: "Attitude of sensor", Offset: 4, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007RSIAttitudeOfSensor},
+	{Name: "Cloud cover", Offset: 5, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007RSICloudCover},
+	{Name: "Platform construction type", Offset: 6, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007RSIPlatformConstructionType},
+	{Name: "Platform use category", Offset: 7, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007RSIPlatformUseCategory},
+	{Name: "Sensor type", Offset: 8, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007RSISensorType},
+	{Name: "Data type", Offset: 9, Width: 2, CodeWidth: 2, FnType: "lookup", Codes: bibliography007RSIDataType},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Bibliography -- 007 -- SOUND RECORDING
 var bibliography007SORSpecificMaterialDesignation = map[string]string{
@@ -1592,6 +1766,25 @@ func parseBibliography007SOR(s string) (pd Cf007Desc) {
 	return pd
 }
 
+// bibliography007SORElements defines the elements that are parsed by
+// parseBibliography007SOR
+var bibliography007SORElements = []ElementDef{
+	{Name: "Category of material", Offset: 0, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007CategoryOfMaterial},
+	{Name: "Specific material designation", Offset: 1, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007SORSpecificMaterialDesignation},
+	{Name: "Undefined", Offset: 2, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Speed", Offset: 3, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007SORSpeed},
+	{Name: "Configuration of playback channels", Offset: 4, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007SORConfigurationOfPlaybackChannels},
+	{Name: "Groove width/groove pitch", Offset: 5, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007SORGrooveWidthGroovePitch},
+	{Name: "Dimensions", Offset: 6, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007SORDimensions},
+	{Name: "Tape width", Offset: 7, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007SORTapeWidth},
+	{Name: "Tape configuration", Offset: 8, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007SORTapeConfiguration},
+	{Name: "Kind of disc, cylinder or tape", Offset: 9, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007SORKindOfDiscCylinderOrTape},
+	{Name: "Kind of material", Offset: 10, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007SORKindOfMaterial},
+	{Name: "Kind of cutting", Offset: 11, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007SORKindOfCutting},
+	{Name: "Special playback characteristics", Offset: 12, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007SORSpecialPlaybackCharacteristics},
+	{Name: "Capture and storage technique", Offset: 13, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007SORCaptureAndStorageTechnique},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Bibliography -- 007 -- TEXT
 var bibliography007TXTSpecificMaterialDesignation = map[string]string{
@@ -1620,6 +1813,13 @@ func parseBibliography007TXT(s string) (pd Cf007Desc) {
 	return pd
 }
 
+// bibliography007TXTElements defines the elements that are parsed by
+// parseBibliography007TXT
+var bibliography007TXTElements = []ElementDef{
+	{Name: "Category of material", Offset: 0, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007CategoryOfMaterial},
+	{Name: "Specific material designation", Offset: 1, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007TXTSpecificMaterialDesignation},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Bibliography -- 007 -- VIDEORECORDING
 var bibliography007VIRSpecificMaterialDesignation = map[string]string{
@@ -1736,6 +1936,20 @@ func parseBibliography007VIR(s string) (pd Cf007Desc) {
 	return pd
 }
 
+// bibliography007VIRElements defines the elements that are parsed by
+// parseBibliography007VIR
+var bibliography007VIRElements = []ElementDef{
+	{Name: "Category of material", Offset: 0, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007CategoryOfMaterial},
+	{Name: "Specific material designation", Offset: 1, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007VIRSpecificMaterialDesignation},
+	{Name: "Undefined", Offset: 2, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Color", Offset: 3, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007VIRColor},
+	{Name: "Videorecording format", Offset: 4, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007VIRVideorecordingFormat},
+	{Name: "Sound on medium or separate", Offset: 5, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007VIRSoundOnMediumOrSeparate},
+	{Name: "Medium for sound", Offset: 6, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007VIRMediumForSound},
+	{Name: "Dimensions", Offset: 7, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007VIRDimensions},
+	{Name: "Configuration of playback channels", Offset: 8, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007VIRConfigurationOfPlaybackChannels},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Bibliography -- 007 -- UNSPECIFIED
 var bibliography007UNSSpecificMaterialDesignation = map[string]string{
@@ -1761,6 +1975,13 @@ func parseBibliography007UNS(s string) (pd Cf007Desc) {
 	return pd
 }
 
+// bibliography007UNSElements defines the elements that are parsed by
+// parseBibliography007UNS
+var bibliography007UNSElements = []ElementDef{
+	{Name: "Category of material", Offset: 0, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007CategoryOfMaterial},
+	{Name: "Specific material designation", Offset: 1, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography007UNSSpecificMaterialDesignation},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Bibliography -- 008 -- ALL MATERIALS
 var bibliography008TypeOfDatePublicationStatus = map[string]string{
@@ -1841,6 +2062,19 @@ func parseBibliography008(d *Cf008Desc, s string) {
 	d.append("(39/01) Cataloging source", CodeValue{Code: c, Label: l, Offset: 39, Width: 1})
 }
 
+// bibliography008Elements defines the elements that are parsed by
+// parseBibliography008
+var bibliography008Elements = []ElementDef{
+	{Name: "Date entered on file", Offset: 0, Width: 6, CodeWidth: 6, FnType: "read"},
+	{Name: "Type of date/Publication status", Offset: 6, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008TypeOfDatePublicationStatus},
+	{Name: "Date 1", Offset: 7, Width: 4, CodeWidth: 1, FnType: "hybrid-date", Codes: bibliography008Date1},
+	{Name: "Date 2", Offset: 11, Width: 4, CodeWidth: 1, FnType: "hybrid-date", Codes: bibliography008Date2},
+	{Name: "Place of publication, production, or execution", Offset: 15, Width: 3, CodeWidth: 3, FnType: "read"},
+	{Name: "Language", Offset: 35, Width: 3, CodeWidth: 3, FnType: "read"},
+	{Name: "Modified record", Offset: 38, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008ModifiedRecord},
+	{Name: "Cataloging source", Offset: 39, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008CatalogingSource},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Bibliography -- 008 -- BOOKS
 var bibliography008BKIllustrations = map[string]string{
@@ -2008,6 +2242,22 @@ func parseBibliography008BK(d *Cf008Desc, s string) {
 	d.append("(34/01) Biography", CodeValue{Code: c, Label: l, Offset: 34, Width: 1})
 }
 
+// bibliography008BKElements defines the elements that are parsed by
+// parseBibliography008BK
+var bibliography008BKElements = []ElementDef{
+	{Name: "Illustrations", Offset: 18, Width: 4, CodeWidth: 1, FnType: "multi", Codes: bibliography008BKIllustrations},
+	{Name: "Target audience", Offset: 22, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008BKTargetAudience},
+	{Name: "Form of item", Offset: 23, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008BKFormOfItem},
+	{Name: "Nature of contents", Offset: 24, Width: 4, CodeWidth: 1, FnType: "multi", Codes: bibliography008BKNatureOfContents},
+	{Name: "Government publication", Offset: 28, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008BKGovernmentPublication},
+	{Name: "Conference publication", Offset: 29, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008BKConferencePublication},
+	{Name: "Festschrift", Offset: 30, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008BKFestschrift},
+	{Name: "Index", Offset: 31, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008BKIndex},
+	{Name: "Undefined", Offset: 32, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Literary form", Offset: 33, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008BKLiteraryForm},
+	{Name: "Biography", Offset: 34, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008BKBiography},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Bibliography -- 008 -- COMPUTER FILES
 var bibliography008CFTargetAudience = map[string]string{
@@ -2077,6 +2327,19 @@ func parseBibliography008CF(d *Cf008Desc, s string) {
 	d.append("(29/06) Undefined", CodeValue{Code: pluckBytes(s, 11, 6), Label: "", Offset: 29, Width: 6})
 }
 
+// bibliography008CFElements defines the elements that are parsed by
+// parseBibliography008CF
+var bibliography008CFElements = []ElementDef{
+	{Name: "Undefined", Offset: 18, Width: 4, CodeWidth: 4, FnType: "read"},
+	{Name: "Target audience", Offset: 22, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008CFTargetAudience},
+	{Name: "Form of item", Offset: 23, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008CFFormOfItem},
+	{Name: "Undefined", Offset: 24, Width: 2, CodeWidth: 2, FnType: "read"},
+	{Name: "Type of computer file", Offset: 26, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008CFTypeOfComputerFile},
+	{Name: "Undefined", Offset: 27, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Government publication", Offset: 28, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008CFGovernmentPublication},
+	{Name: "Undefined", Offset: 29, Width: 6, CodeWidth: 6, FnType: "read"},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Bibliography -- 008 -- MAPS
 var bibliography008MPRelief = map[string]string{
@@ -2237,6 +2500,22 @@ func parseBibliography008MP(d *Cf008Desc, s string) {
 
 }
 
+// bibliography008MPElements defines the elements that are parsed by
+// parseBibliography008MP
+var bibliography008MPElements = []ElementDef{
+	{Name: "Relief", Offset: 18, Width: 4, CodeWidth: 1, FnType: "multi", Codes: bibliography008MPRelief},
+	{Name: "Projection", Offset: 22, Width: 2, CodeWidth: 2, FnType: "lookup", Codes: bibliography008MPProjection},
+	{Name: "Undefined", Offset: 24, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Type of cartographic material", Offset: 25, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008MPTypeOfCartographicMaterial},
+	{Name: "Undefined", Offset: 26, Width: 2, CodeWidth: 2, FnType: "read"},
+	{Name: "Government publication", Offset: 28, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008MPGovernmentPublication},
+	{Name: "Form of item", Offset: 29, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008MPFormOfItem},
+	{Name: "Undefined", Offset: 30, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Index", Offset: 31, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008MPIndex},
+	{Name: "Undefined", Offset: 32, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Special format characteristics", Offset: 33, Width: 2, CodeWidth: 1, FnType: "multi", Codes: bibliography008MPSpecialFormatCharacteristics},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Bibliography -- 008 -- MUSIC
 var bibliography008MUFormOfComposition = map[string]string{
@@ -2452,6 +2731,21 @@ func parseBibliography008MU(d *Cf008Desc, s string) {
 	d.append("(34/01) Undefined", CodeValue{Code: pluckBytes(s, 16, 1), Label: "", Offset: 34, Width: 1})
 }
 
+// bibliography008MUElements defines the elements that are parsed by
+// parseBibliography008MU
+var bibliography008MUElements = []ElementDef{
+	{Name: "Form of composition", Offset: 18, Width: 2, CodeWidth: 2, FnType: "lookup", Codes: bibliography008MUFormOfComposition},
+	{Name: "Format of music", Offset: 20, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008MUFormatOfMusic},
+	{Name: "Music parts", Offset: 21, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008MUMusicParts},
+	{Name: "Target audience", Offset: 22, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008MUTargetAudience},
+	{Name: "Form of item", Offset: 23, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008MUFormOfItem},
+	{Name: "Accompanying matter", Offset: 24, Width: 6, CodeWidth: 1, FnType: "multi", Codes: bibliography008MUAccompanyingMatter},
+	{Name: "Literary text for sound recordings", Offset: 30, Width: 2, CodeWidth: 1, FnType: "multi", Codes: bibliography008MULiteraryTextForSoundRecordings},
+	{Name: "Undefined", Offset: 32, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Transposition and arrangement", Offset: 33, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008MUTranspositionAndArrangement},
+	{Name: "Undefined", Offset: 34, Width: 1, CodeWidth: 1, FnType: "read"},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Bibliography -- 008 -- CONTINUING RESOURCES
 var bibliography008CRFrequency = map[string]string{
@@ -2658,6 +2952,23 @@ func parseBibliography008CR(d *Cf008Desc, s string) {
 	d.append("(34/01) Entry convention", CodeValue{Code: c, Label: l, Offset: 34, Width: 1})
 }
 
+// bibliography008CRElements defines the elements that are parsed by
+// parseBibliography008CR
+var bibliography008CRElements = []ElementDef{
+	{Name: "Frequency", Offset: 18, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008CRFrequency},
+	{Name: "Regularity", Offset: 19, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008CRRegularity},
+	{Name: "Type of continuing resource", Offset: 21, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008CRTypeOfContinuingResource},
+	{Name: "Form of original item", Offset: 22, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008CRFormOfOriginalItem},
+	{Name: "Form of item", Offset: 23, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008CRFormOfItem},
+	{Name: "Nature of entire work", Offset: 24, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008CRNatureOfEntireWork},
+	{Name: "Nature of contents", Offset: 25, Width: 3, CodeWidth: 1, FnType: "multi", Codes: bibliography008CRNatureOfContents},
+	{Name: "Government publication", Offset: 28, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008CRGovernmentPublication},
+	{Name: "Conference publication", Offset: 29, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008CRConferencePublication},
+	{Name: "Undefined", Offset: 30, Width: 3, CodeWidth: 3, FnType: "read"},
+	{Name: "Original alphabet or script of title", Offset: 33, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008CROriginalAlphabetOrScriptOfTitle},
+	{Name: "Entry convention", Offset: 34, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008CREntryConvention},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Bibliography -- 008 -- VISUAL MATERIALS
 var bibliography008VMRunningTimeForMotionPicturesAndVideorecordings = map[string]string{
@@ -2767,6 +3078,20 @@ func parseBibliography008VM(d *Cf008Desc, s string) {
 	d.append("(34/01) Technique", CodeValue{Code: c, Label: l, Offset: 34, Width: 1})
 }
 
+// bibliography008VMElements defines the elements that are parsed by
+// parseBibliography008VM
+var bibliography008VMElements = []ElementDef{
+	{Name: "Running time for motion pictures and videorecordings", Offset: 18, Width: 3, CodeWidth: 3, FnType: "hybrid", Codes: bibliography008VMRunningTimeForMotionPicturesAndVideorecordings, RangeLabel: "Running time"},
+	{Name: "Undefined", Offset: 21, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Target audience", Offset: 22, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008VMTargetAudience},
+	{Name: "Undefined", Offset: 23, Width: 5, CodeWidth: 5, FnType: "read"},
+	{Name: "Government publication", Offset: 28, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008VMGovernmentPublication},
+	{Name: "Form of item", Offset: 29, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008VMFormOfItem},
+	{Name: "Undefined", Offset: 30, Width: 3, CodeWidth: 3, FnType: "read"},
+	{Name: "Type of visual material", Offset: 33, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008VMTypeOfVisualMaterial},
+	{Name: "Technique", Offset: 34, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008VMTechnique},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Bibliography -- 008 -- MIXED MATERIALS
 var bibliography008MXFormOfItem = map[string]string{
@@ -2795,6 +3120,14 @@ func parseBibliography008MX(d *Cf008Desc, s string) {
 	d.append("(24/11) Undefined", CodeValue{Code: pluckBytes(s, 6, 11), Label: "", Offset: 24, Width: 11})
 }
 
+// bibliography008MXElements defines the elements that are parsed by
+// parseBibliography008MX
+var bibliography008MXElements = []ElementDef{
+	{Name: "Undefined", Offset: 18, Width: 5, CodeWidth: 5, FnType: "read"},
+	{Name: "Form of item", Offset: 23, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography008MXFormOfItem},
+	{Name: "Undefined", Offset: 24, Width: 11, CodeWidth: 11, FnType: "read"},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Classification
 ////////////////////////////////////////////////////////////////////////
@@ -2866,6 +3199,20 @@ func parseClassification008(d *Cf008Desc, s string) {
 	d.append("(13/01) Display controller", CodeValue{Code: c, Label: l, Offset: 13, Width: 1})
 }
 
+// classification008Elements defines the elements that are parsed by
+// parseClassification008
+var classification008Elements = []ElementDef{
+	{Name: "Date entered on file", Offset: 0, Width: 6, CodeWidth: 6, FnType: "read"},
+	{Name: "Kind of record", Offset: 6, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: classification008KindOfRecord},
+	{Name: "Type of number", Offset: 7, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: classification008TypeOfNumber},
+	{Name: "Classification validity", Offset: 8, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: classification008ClassificationValidity},
+	{Name: "Standard or optional designation", Offset: 9, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: classification008StandardOrOptionalDesignation},
+	{Name: "Record update in process", Offset: 10, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: classification008RecordUpdateInProcess},
+	{Name: "Level of establishment", Offset: 11, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: classification008LevelOfEstablishment},
+	{Name: "Synthesized number indication", Offset: 12, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: classification008SynthesizedNumberIndication},
+	{Name: "Display controller", Offset: 13, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: classification008DisplayController},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Community
 ////////////////////////////////////////////////////////////////////////
@@ -2984,6 +3331,22 @@ func parseCommunity007(s string) (pd Cf007Desc) {
 	return pd
 }
 
+// community007Elements defines the elements that are parsed by
+// parseCommunity007
+var community007Elements = []ElementDef{
+	{Name: "Category", Offset: 0, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: community007Category},
+	{Name: "Stairway ramps", Offset: 1, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: community007StairwayRamps},
+	{Name: "Doors", Offset: 2, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: community007Doors},
+	{Name: "Furniture, equipment, display racks", Offset: 3, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: community007FurnitureEquipmentDisplayRacks},
+	{Name: "Restrooms", Offset: 4, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: community007Restrooms},
+	{Name: "Elevators", Offset: 5, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: community007Elevators},
+	{Name: "Telephones", Offset: 6, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: community007Telephones},
+	{Name: "Flashing emergency lights", Offset: 7, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: community007FlashingEmergencyLights},
+	{Name: "Sign language", Offset: 8, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: community007SignLanguage},
+	{Name: "Subtitles and/or supertitles", Offset: 9, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: community007SubtitlesAndOrSupertitles},
+	{Name: "Parking", Offset: 10, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: community007Parking},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Community -- 008
 var community008VolunteerOpportunities = map[string]string{
@@ -3045,6 +3408,19 @@ func parseCommunity008(d *Cf008Desc, s string) {
 	d.append("(12/03) Language", CodeValue{Code: pluckBytes(s, 12, 3), Label: "", Offset: 12, Width: 3})
 }
 
+// community008Elements defines the elements that are parsed by
+// parseCommunity008
+var community008Elements = []ElementDef{
+	{Name: "Date entered on file", Offset: 0, Width: 6, CodeWidth: 6, FnType: "read"},
+	{Name: "Volunteer opportunities", Offset: 6, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: community008VolunteerOpportunities},
+	{Name: "Volunteers provided", Offset: 7, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: community008VolunteersProvided},
+	{Name: "Child care arrangements", Offset: 8, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: community008ChildCareArrangements},
+	{Name: "Speakers bureau", Offset: 9, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: community008SpeakersBureau},
+	{Name: "Mutual support groups", Offset: 10, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: community008MutualSupportGroups},
+	{Name: "Meeting rooms and facilities available", Offset: 11, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: community008MeetingRoomsAndFacilitiesAvailable},
+	{Name: "Language", Offset: 12, Width: 3, CodeWidth: 3, FnType: "read"},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Holdings
 ////////////////////////////////////////////////////////////////////////
@@ -3162,6 +3538,19 @@ func parseHoldings007MAP(s string) (pd Cf007Desc) {
 	return pd
 }
 
+// holdings007MAPElements defines the elements that are parsed by
+// parseHoldings007MAP
+var holdings007MAPElements = []ElementDef{
+	{Name: "Category of material", Offset: 0, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007CategoryOfMaterial},
+	{Name: "Specific material designation", Offset: 1, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007MAPSpecificMaterialDesignation},
+	{Name: "Undefined", Offset: 2, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Color", Offset: 3, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007MAPColor},
+	{Name: "Physical medium", Offset: 4, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007MAPPhysicalMedium},
+	{Name: "Type of reproduction", Offset: 5, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007MAPTypeOfReproduction},
+	{Name: "Production/reproduction details", Offset: 6, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007MAPProductionReproductionDetails},
+	{Name: "Positive/negative aspect", Offset: 7, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007MAPPositiveNegativeAspect},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Holdings -- 007 -- ELECTRONIC RESOURCE
 var holdings007ELRSpecificMaterialDesignation = map[string]string{
@@ -3298,6 +3687,23 @@ func parseHoldings007ELR(s string) (pd Cf007Desc) {
 	return pd
 }
 
+// holdings007ELRElements defines the elements that are parsed by
+// parseHoldings007ELR
+var holdings007ELRElements = []ElementDef{
+	{Name: "Category of material", Offset: 0, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007CategoryOfMaterial},
+	{Name: "Specific material designation", Offset: 1, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007ELRSpecificMaterialDesignation},
+	{Name: "Undefined", Offset: 2, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Color", Offset: 3, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007ELRColor},
+	{Name: "Dimensions", Offset: 4, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007ELRDimensions},
+	{Name: "Sound", Offset: 5, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007ELRSound},
+	{Name: "Image bit depth", Offset: 6, Width: 3, CodeWidth: 3, FnType: "hybrid", Codes: holdings007ELRImageBitDepth, RangeLabel: "Exact bit depth"},
+	{Name: "File formats", Offset: 9, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007ELRFileFormats},
+	{Name: "Quality assurance target(s)", Offset: 10, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007ELRQualityAssuranceTargetS},
+	{Name: "Antecedent/source", Offset: 11, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007ELRAntecedentSource},
+	{Name: "Level of compression", Offset: 12, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007ELRLevelOfCompression},
+	{Name: "Reformatting quality", Offset: 13, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007ELRReformattingQuality},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Holdings -- 007 -- GLOBE
 var holdings007GLBSpecificMaterialDesignation = map[string]string{
@@ -3363,6 +3769,17 @@ func parseHoldings007GLB(s string) (pd Cf007Desc) {
 	return pd
 }
 
+// holdings007GLBElements defines the elements that are parsed by
+// parseHoldings007GLB
+var holdings007GLBElements = []ElementDef{
+	{Name: "Category of material", Offset: 0, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007CategoryOfMaterial},
+	{Name: "Specific material designation", Offset: 1, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007GLBSpecificMaterialDesignation},
+	{Name: "Undefined", Offset: 2, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Color", Offset: 3, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007GLBColor},
+	{Name: "Physical medium", Offset: 4, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007GLBPhysicalMedium},
+	{Name: "Type of reproduction", Offset: 5, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007GLBTypeOfReproduction},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Holdings -- 007 -- TACTILE MATERIAL
 var holdings007TAMSpecificMaterialDesignation = map[string]string{
@@ -3459,6 +3876,18 @@ func parseHoldings007TAM(s string) (pd Cf007Desc) {
 	return pd
 }
 
+// holdings007TAMElements defines the elements that are parsed by
+// parseHoldings007TAM
+var holdings007TAMElements = []ElementDef{
+	{Name: "Category of material", Offset: 0, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007CategoryOfMaterial},
+	{Name: "Specific material designation", Offset: 1, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007TAMSpecificMaterialDesignation},
+	{Name: "Undefined", Offset: 2, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Class of braille writing", Offset: 3, Width: 2, CodeWidth: 1, FnType: "multi", Codes: holdings007TAMClassOfBrailleWriting},
+	{Name: "Level of contraction", Offset: 5, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007TAMLevelOfContraction},
+	{Name: "Braille music format", Offset: 6, Width: 3, CodeWidth: 1, FnType: "multi", Codes: holdings007TAMBrailleMusicFormat},
+	{Name: "Specific physical characteristics", Offset: 9, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007TAMSpecificPhysicalCharacteristics},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Holdings -- 007 -- PROJECTED GRAPHIC
 var holdings007PRGSpecificMaterialDesignation = map[string]string{
@@ -3579,6 +4008,20 @@ func parseHoldings007PRG(s string) (pd Cf007Desc) {
 	return pd
 }
 
+// holdings007PRGElements defines the elements that are parsed by
+// parseHoldings007PRG
+var holdings007PRGElements = []ElementDef{
+	{Name: "Category of material", Offset: 0, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007CategoryOfMaterial},
+	{Name: "Specific material designation", Offset: 1, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007PRGSpecificMaterialDesignation},
+	{Name: "Undefined", Offset: 2, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Color", Offset: 3, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007PRGColor},
+	{Name: "Base of emulsion", Offset: 4, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007PRGBaseOfEmulsion},
+	{Name: "Sound on medium or separate", Offset: 5, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007PRGSoundOnMediumOrSeparate},
+	{Name: "Medium for sound", Offset: 6, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007PRGMediumForSound},
+	{Name: "Dimensions", Offset: 7, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007PRGDimensions},
+	{Name: "Secondary support material", Offset: 8, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007PRGSecondarySupportMaterial},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Holdings -- 007 -- MICROFORM
 var holdings007MICSpecificMaterialDesignation = map[string]string{
@@ -3699,6 +4142,22 @@ func parseHoldings007MIC(s string) (pd Cf007Desc) {
 	return pd
 }
 
+// holdings007MICElements defines the elements that are parsed by
+// parseHoldings007MIC
+var holdings007MICElements = []ElementDef{
+	{Name: "Category of material", Offset: 0, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007CategoryOfMaterial},
+	{Name: "Specific material designation", Offset: 1, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007MICSpecificMaterialDesignation},
+	{Name: "Undefined", Offset: 2, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Positive/negative aspect", Offset: 3, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007MICPositiveNegativeAspect},
+	{Name: "Dimensions", Offset: 4, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007MICDimensions},
+	{Name: "Reduction ratio range", Offset: 5, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007MICReductionRatioRange},
+	{Name: "Reduction ratio", Offset: 6, Width: 3, CodeWidth: 3, FnType: "read"},
+	{Name: "Color", Offset: 9, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007MICColor},
+	{Name: "Emulsion on film", Offset: 10, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007MICEmulsionOnFilm},
+	{Name: "Generation", Offset: 11, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007MICGeneration},
+	{Name: "Base of film", Offset: 12, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007MICBaseOfFilm},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Holdings -- 007 -- NONPROJECTED GRAPHIC
 var holdings007NPGSpecificMaterialDesignation = map[string]string{
@@ -3809,6 +4268,17 @@ func parseHoldings007NPG(s string) (pd Cf007Desc) {
 	return pd
 }
 
+// holdings007NPGElements defines the elements that are parsed by
+// parseHoldings007NPG
+var holdings007NPGElements = []ElementDef{
+	{Name: "Category of material", Offset: 0, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007CategoryOfMaterial},
+	{Name: "Specific material designation", Offset: 1, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007NPGSpecificMaterialDesignation},
+	{Name: "Undefined", Offset: 2, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Color", Offset: 3, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007NPGColor},
+	{Name: "Primary support material", Offset: 4, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007NPGPrimarySupportMaterial},
+	{Name: "Secondary support material", Offset: 5, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007NPGSecondarySupportMaterial},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Holdings -- 007 -- MOTION PICTURE
 var holdings007MOPSpecificMaterialDesignation = map[string]string{
@@ -4031,6 +4501,29 @@ func parseHoldings007MOP(s string) (pd Cf007Desc) {
 	return pd
 }
 
+// holdings007MOPElements defines the elements that are parsed by
+// parseHoldings007MOP
+var holdings007MOPElements = []ElementDef{
+	{Name: "Category of material", Offset: 0, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007CategoryOfMaterial},
+	{Name: "Specific material designation", Offset: 1, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007MOPSpecificMaterialDesignation},
+	{Name: "Undefined", Offset: 2, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Color", Offset: 3, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007MOPColor},
+	{Name: "Motion picture presentation format", Offset: 4, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007MOPMotionPicturePresentationFormat},
+	{Name: "Sound on medium or separate", Offset: 5, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007MOPSoundOnMediumOrSeparate},
+	{Name: "Medium for sound", Offset: 6, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007MOPMediumForSound},
+	{Name: "Dimensions", Offset: 7, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007MOPDimensions},
+	{Name: "Configuration of playback channels", Offset: 8, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007MOPConfigurationOfPlaybackChannels},
+	{Name: "Production elements", Offset: 9, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007MOPProductionElements},
+	{Name: "Positive/negative aspect", Offset: 10, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007MOPPositiveNegativeAspect},
+	{Name: "Generation", Offset: 11, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007MOPGeneration},
+	{Name: "Base of film", Offset: 12, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007MOPBaseOfFilm},
+	{Name: "Refined categories of color", Offset: 13, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007MOPRefinedCategoriesOfColor},
+	{Name: "Kind of color stock or print", Offset: 14, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007MOPKindOfColorStockOrPrint},
+	{Name: "Deterioration stage", Offset: 15, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007MOPDeteriorationStage},
+	{Name: "Completeness", Offset: 16, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007MOPCompleteness},
+	{Name: "Film inspection date", Offset: 17, Width: 6, CodeWidth: 6, FnType: "read"},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Holdings -- 007 -- KIT
 var holdings007KITSpecificMaterialDesignation = map[string]string{
@@ -4054,6 +4547,13 @@ func parseHoldings007KIT(s string) (pd Cf007Desc) {
 	return pd
 }
 
+// holdings007KITElements defines the elements that are parsed by
+// parseHoldings007KIT
+var holdings007KITElements = []ElementDef{
+	{Name: "Category of material", Offset: 0, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007CategoryOfMaterial},
+	{Name: "Specific material designation", Offset: 1, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007KITSpecificMaterialDesignation},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Holdings -- 007 -- NOTATED MUSIC
 var holdings007NMUSpecificMaterialDesignation = map[string]string{
@@ -4077,6 +4577,13 @@ func parseHoldings007NMU(s string) (pd Cf007Desc) {
 	return pd
 }
 
+// holdings007NMUElements defines the elements that are parsed by
+// parseHoldings007NMU
+var holdings007NMUElements = []ElementDef{
+	{Name: "Category of material", Offset: 0, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007CategoryOfMaterial},
+	{Name: "Specific material designation", Offset: 1, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007NMUSpecificMaterialDesignation},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Holdings -- 007 -- REMOTE-SENSING IMAGE
 var holdings007RSISpecificMaterialDesignation = map[string]string{
@@ -4223,6 +4730,21 @@ func parseHoldings007RSI(s string) (pd Cf007Desc) {
 	return pd
 }
 
+// holdings007RSIElements defines the elements that are parsed by
+// parseHoldings007RSI
+var holdings007RSIElements = []ElementDef{
+	{Name: "Category of material", Offset: 0, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007CategoryOfMaterial},
+	{Name: "Specific material designation", Offset: 1, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007RSISpecificMaterialDesignation},
+	{Name: "Undefined", Offset: 2, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Altitude of sensor", Offset: 3, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007RSIAltitudeOfSensor},
+	{Name: "Attitude of sensor", Offset: 4, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007RSIAttitudeOfSensor},
+	{Name: "Cloud cover", Offset: 5, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007RSICloudCover},
+	{Name: "Platform construction type", Offset: 6, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007RSIPlatformConstructionType},
+	{Name: "Platform use category", Offset: 7, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007RSIPlatformUseCategory},
+	{Name: "Sensor type", Offset: 8, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007RSISensorType},
+	{Name: "Data type", Offset: 9, Width: 2, CodeWidth: 2, FnType: "lookup", Codes: holdings007RSIDataType},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Holdings -- 007 -- SOUND RECORDING
 var holdings007SORSpecificMaterialDesignation = map[string]string{
@@ -4411,6 +4933,25 @@ func parseHoldings007SOR(s string) (pd Cf007Desc) {
 	return pd
 }
 
+// holdings007SORElements defines the elements that are parsed by
+// parseHoldings007SOR
+var holdings007SORElements = []ElementDef{
+	{Name: "Category of material", Offset: 0, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007CategoryOfMaterial},
+	{Name: "Specific material designation", Offset: 1, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007SORSpecificMaterialDesignation},
+	{Name: "Undefined", Offset: 2, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Speed", Offset: 3, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007SORSpeed},
+	{Name: "Configuration of playback channels", Offset: 4, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007SORConfigurationOfPlaybackChannels},
+	{Name: "Groove width/groove pitch", Offset: 5, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007SORGrooveWidthGroovePitch},
+	{Name: "Dimensions", Offset: 6, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007SORDimensions},
+	{Name: "Tape width", Offset: 7, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007SORTapeWidth},
+	{Name: "Tape configuration", Offset: 8, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007SORTapeConfiguration},
+	{Name: "Kind of disc, cylinder or tape", Offset: 9, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007SORKindOfDiscCylinderOrTape},
+	{Name: "Kind of material", Offset: 10, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007SORKindOfMaterial},
+	{Name: "Kind of cutting", Offset: 11, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007SORKindOfCutting},
+	{Name: "Special playback characteristics", Offset: 12, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007SORSpecialPlaybackCharacteristics},
+	{Name: "Capture and storage technique", Offset: 13, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007SORCaptureAndStorageTechnique},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Holdings -- 007 -- TEXT
 var holdings007TXTSpecificMaterialDesignation = map[string]string{
@@ -4439,6 +4980,13 @@ func parseHoldings007TXT(s string) (pd Cf007Desc) {
 	return pd
 }
 
+// holdings007TXTElements defines the elements that are parsed by
+// parseHoldings007TXT
+var holdings007TXTElements = []ElementDef{
+	{Name: "Category of material", Offset: 0, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007CategoryOfMaterial},
+	{Name: "Specific material designation", Offset: 1, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007TXTSpecificMaterialDesignation},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Holdings -- 007 -- VIDEORECORDING
 var holdings007VIRSpecificMaterialDesignation = map[string]string{
@@ -4555,6 +5103,20 @@ func parseHoldings007VIR(s string) (pd Cf007Desc) {
 	return pd
 }
 
+// holdings007VIRElements defines the elements that are parsed by
+// parseHoldings007VIR
+var holdings007VIRElements = []ElementDef{
+	{Name: "Category of material", Offset: 0, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007CategoryOfMaterial},
+	{Name: "Specific material designation", Offset: 1, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007VIRSpecificMaterialDesignation},
+	{Name: "Undefined", Offset: 2, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Color", Offset: 3, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007VIRColor},
+	{Name: "Videorecording format", Offset: 4, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007VIRVideorecordingFormat},
+	{Name: "Sound on medium or separate", Offset: 5, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007VIRSoundOnMediumOrSeparate},
+	{Name: "Medium for sound", Offset: 6, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007VIRMediumForSound},
+	{Name: "Dimensions", Offset: 7, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007VIRDimensions},
+	{Name: "Configuration of playback channels", Offset: 8, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007VIRConfigurationOfPlaybackChannels},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Holdings -- 007 -- UNSPECIFIED
 var holdings007UNSSpecificMaterialDesignation = map[string]string{
@@ -4580,6 +5142,13 @@ func parseHoldings007UNS(s string) (pd Cf007Desc) {
 	return pd
 }
 
+// holdings007UNSElements defines the elements that are parsed by
+// parseHoldings007UNS
+var holdings007UNSElements = []ElementDef{
+	{Name: "Category of material", Offset: 0, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007CategoryOfMaterial},
+	{Name: "Specific material designation", Offset: 1, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings007UNSSpecificMaterialDesignation},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Holdings -- 008
 var holdings008ReceiptOrAcquisitionStatus = map[string]string{
@@ -4690,3 +5259,23 @@ func parseHoldings008(d *Cf008Desc, s string) {
 	d.append("(25/01) Separate or composite copy report", CodeValue{Code: c, Label: l, Offset: 25, Width: 1})
 	d.append("(26/06) Date of report", CodeValue{Code: pluckBytes(s, 26, 6), Label: "", Offset: 26, Width: 6})
 }
+
+// holdings008Elements defines the elements that are parsed by
+// parseHoldings008
+var holdings008Elements = []ElementDef{
+	{Name: "Date entered on file", Offset: 0, Width: 6, CodeWidth: 6, FnType: "read"},
+	{Name: "Receipt or acquisition status", Offset: 6, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings008ReceiptOrAcquisitionStatus},
+	{Name: "Method of acquisition", Offset: 7, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings008MethodOfAcquisition},
+	{Name: "Expected acquisition end date", Offset: 8, Width: 4, CodeWidth: 6, FnType: "hybrid", Codes: holdings008ExpectedAcquisitionEndDate, RangeLabel: "Date of cancellation or last expected part"},
+	{Name: "General retention policy", Offset: 12, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings008GeneralRetentionPolicy},
+	{Name: "Policy Type", Offset: 13, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings008PolicyType},
+	{Name: "Number of units", Offset: 14, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Unit type", Offset: 15, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Completeness", Offset: 16, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings008Completeness},
+	{Name: "Number of copies reported", Offset: 17, Width: 3, CodeWidth: 3, FnType: "read"},
+	{Name: "Lending policy", Offset: 20, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings008LendingPolicy},
+	{Name: "Reproduction policy", Offset: 21, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings008ReproductionPolicy},
+	{Name: "Language", Offset: 22, Width: 3, CodeWidth: 3, FnType: "lookup", Codes: holdings008Language},
+	{Name: "Separate or composite copy report", Offset: 25, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdings008SeparateOrCompositeCopyReport},
+	{Name: "Date of report", Offset: 26, Width: 6, CodeWidth: 6, FnType: "read"},
+}
@@ -81,7 +81,7 @@ func Parse006(rec marc21.Record) (d Cf008Desc) {
 			d.append("(00/01) Form of material", CodeValue{Code: code, Label: label, Offset: 0, Width: 1})
 
 			fcn, ok := m[c]
-			if ok {
+			if ok && len(cf6.Text) > 0 {
 				fcn(&d, cf6.Text[1:])
 			}
 			//}
@@ -120,10 +120,11 @@ func Parse008(rec marc21.Record) (d Cf008Desc) {
 		// For bibliography 008 fields pass subslice of s -- s[18:]
 		// and for bibliography 006 fields pass subslice of s -- s[1:]
 		// This way the same parsing functions can parse both 008 and
-		// 006 control fields
+		// 006 control fields. The material specific elements are
+		// skipped if the 008 is missing or too short to contain them.
 
 		fcn, ok := m[c]
-		if ok {
+		if ok && len(s) >= 18 {
 			fcn(&d, s[18:])
 		}
 
@@ -0,0 +1,118 @@
+// Copyright 2017-2018 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package details
+
+/*
+http://www.loc.gov/marc/countries/countries_code.html
+
+The 008/15-17 (Place of publication, production, or execution) element
+is a "read" element so there is no generated lookup list for it. This
+is a subset of the MARC Code List for Countries that covers the places
+most commonly encountered; codes that are not listed here are reported
+as-is. Codes are listed without the trailing blank of two character
+codes.
+*/
+
+var countryNames = map[string]string{
+	"abc": "Alberta",
+	"ag":  "Argentina",
+	"at":  "Australia",
+	"au":  "Austria",
+	"azu": "Arizona",
+	"bcc": "British Columbia",
+	"be":  "Belgium",
+	"bl":  "Brazil",
+	"bu":  "Bulgaria",
+	"cau": "California",
+	"cc":  "China",
+	"ch":  "China (Republic : 1949- )",
+	"ck":  "Colombia",
+	"cl":  "Chile",
+	"cou": "Colorado",
+	"ctu": "Connecticut",
+	"dcu": "District of Columbia",
+	"dk":  "Denmark",
+	"enk": "England",
+	"et":  "Ethiopia",
+	"fi":  "Finland",
+	"flu": "Florida",
+	"fr":  "France",
+	"gau": "Georgia",
+	"gr":  "Greece",
+	"gw":  "Germany",
+	"hu":  "Hungary",
+	"ie":  "Ireland",
+	"ii":  "India",
+	"ilu": "Illinois",
+	"inu": "Indiana",
+	"io":  "Indonesia",
+	"iq":  "Iraq",
+	"ir":  "Iran",
+	"is":  "Israel",
+	"it":  "Italy",
+	"ja":  "Japan",
+	"ke":  "Kenya",
+	"ko":  "Korea (South)",
+	"le":  "Lebanon",
+	"mau": "Massachusetts",
+	"mbc": "Manitoba",
+	"mdu": "Maryland",
+	"miu": "Michigan",
+	"mnu": "Minnesota",
+	"mou": "Missouri",
+	"mr":  "Morocco",
+	"mx":  "Mexico",
+	"my":  "Malaysia",
+	"ncu": "North Carolina",
+	"ne":  "Netherlands",
+	"nik": "Northern Ireland",
+	"nju": "New Jersey",
+	"no":  "Norway",
+	"nr":  "Nigeria",
+	"nsc": "Nova Scotia",
+	"nyu": "New York (State)",
+	"nz":  "New Zealand",
+	"ohu": "Ohio",
+	"onc": "Ontario",
+	"oru": "Oregon",
+	"pau": "Pennsylvania",
+	"pe":  "Peru",
+	"ph":  "Philippines",
+	"pk":  "Pakistan",
+	"pl":  "Poland",
+	"po":  "Portugal",
+	"qea": "Queensland",
+	"quc": "Québec (Province)",
+	"rm":  "Romania",
+	"ru":  "Russia (Federation)",
+	"sa":  "South Africa",
+	"si":  "Singapore",
+	"sp":  "Spain",
+	"stk": "Scotland",
+	"su":  "Saudi Arabia",
+	"sw":  "Sweden",
+	"sz":  "Switzerland",
+	"th":  "Thailand",
+	"tnu": "Tennessee",
+	"ts":  "United Arab Emirates",
+	"tu":  "Turkey",
+	"txu": "Texas",
+	"ua":  "Egypt",
+	"un":  "Ukraine",
+	"vau": "Virginia",
+	"ve":  "Venezuela",
+	"vm":  "Vietnam",
+	"vp":  "Various places",
+	"vra": "Victoria",
+	"wau": "Washington (State)",
+	"wiu": "Wisconsin",
+	"wlk": "Wales",
+	"xna": "New South Wales",
+	"xr":  "Czech Republic",
+	"xx":  "No place, unknown, or undetermined",
+	"xxc": "Canada",
+	"xxk": "United Kingdom",
+	"xxu": "United States",
+}
@@ -0,0 +1,449 @@
+// Copyright 2017-2018 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package details
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/gsiems/go-marc21/pkg/marc21"
+)
+
+/*
+Cross-field validation checks that the leader and 008 of bibliography
+records agree with the variable fields of the record. Each issue
+reports both values, with labels where available.
+*/
+
+// crossFieldRules is the list of cross-field validation rules
+var crossFieldRules = []Rule{
+	{
+		ID:          "language-041",
+		Description: "008/35-37 (Language) should be one of the 041 $a languages",
+		Severity:    SeverityWarning,
+		check:       checkLanguage041,
+	},
+	{
+		ID:          "place-044",
+		Description: "008/15-17 (Place of publication) should be one of the 044 $a places",
+		Severity:    SeverityWarning,
+		check:       checkPlace044,
+	},
+	{
+		ID:          "date-260-264",
+		Description: "008/07-10 (Date 1) should agree with the 260 or 264 $c date",
+		Severity:    SeverityWarning,
+		check:       checkDate260,
+	},
+	{
+		ID:          "index-note",
+		Description: "008/31 (BK Index) should agree with the 500 and 504 notes",
+		Severity:    SeverityWarning,
+		check:       checkIndexNote,
+	},
+	{
+		ID:          "illustrations-300",
+		Description: "008/18-21 (BK Illustrations) should agree with 300 $b",
+		Severity:    SeverityWarning,
+		check:       checkIllustrations300,
+	},
+	{
+		ID:          "conference-111-711",
+		Description: "008/29 (Conference publication) should agree with the presence of 111 or 711",
+		Severity:    SeverityWarning,
+		check:       checkConference111,
+	},
+	{
+		ID:          "thesis-502",
+		Description: "008/24-27 (BK Nature of contents) code m (Theses) should agree with the presence of 502",
+		Severity:    SeverityWarning,
+		check:       checkThesis502,
+	},
+	{
+		ID:          "cataloging-form-040",
+		Description: "Leader/18 (Descriptive cataloging form) should agree with 040 $e",
+		Severity:    SeverityWarning,
+		check:       checkCatalogingForm040,
+	},
+}
+
+var (
+	reYear       = regexp.MustCompile(`[0-9]{4}`)
+	reIndexNote  = regexp.MustCompile(`(?i)\bindex(es)?\b`)
+	reDateDigits = regexp.MustCompile(`^[0-9u]{4}$`)
+)
+
+// elementsByKey returns the decoded elements that have the specified
+// key
+func elementsByKey(rd RecordDesc, key string) (l []ElementDesc) {
+	for _, fd := range rd.Fields {
+		for _, e := range fd.Elements {
+			if e.Key == key {
+				l = append(l, e)
+			}
+		}
+	}
+	return l
+}
+
+// firstByKey returns the first decoded element that has the specified
+// key
+func firstByKey(rd RecordDesc, key string) (e ElementDesc, ok bool) {
+	l := elementsByKey(rd, key)
+	if len(l) == 0 {
+		return e, false
+	}
+	return l[0], true
+}
+
+// subfieldValues returns the trimmed values of the specified subfield
+// codes for the specified data field tags
+func subfieldValues(rec marc21.Record, tags, codes string) (l []string) {
+	for _, df := range rec.GetDatafields(tags) {
+		for _, sf := range df.GetSubfields(codes) {
+			if v := strings.TrimSpace(sf.GetText()); v != "" {
+				l = append(l, v)
+			}
+		}
+	}
+	return l
+}
+
+// quoteCode returns a code, and its label if known, for use in issue
+// messages
+func quoteCode(code, label string) string {
+	code = strings.Replace(code, " ", "#", -1)
+	if label == "" {
+		return fmt.Sprintf("%q", code)
+	}
+	return fmt.Sprintf("%q (%s)", code, label)
+}
+
+// quoteCodes returns a list of codes, and their labels if known, for
+// use in issue messages
+func quoteCodes(codes []string, labels map[string]string) string {
+	var l []string
+	for _, c := range codes {
+		l = append(l, quoteCode(c, labels[c]))
+	}
+	return strings.Join(l, ", ")
+}
+
+// isCoded determines if an element contains a value (it is not
+// missing, blank or fill)
+func isCoded(e ElementDesc) bool {
+	return e.Status != StatusMissing && strings.Trim(e.Code, " |") != ""
+}
+
+func checkLanguage041(rec marc21.Record, rd RecordDesc) (l []Issue) {
+
+	lang, ok := firstByKey(rd, "008.language")
+	if !ok || !isCoded(lang) {
+		return l
+	}
+
+	var codes []string
+	for _, v := range subfieldValues(rec, "041", "a") {
+		// Older records may have several codes run together in one
+		// subfield
+		for i := 0; i+3 <= len(v); i += 3 {
+			codes = append(codes, v[i:i+3])
+		}
+	}
+	if len(codes) == 0 || lang.Code == "mul" {
+		return l
+	}
+
+	for _, c := range codes {
+		if c == lang.Code {
+			return l
+		}
+	}
+
+	return append(l, Issue{
+		Tag:       "008",
+		Positions: lang.Positions,
+		Message: fmt.Sprintf("Language %s is not one of the 041 $a languages %s",
+			quoteCode(lang.Code, languageNames[lang.Code]), quoteCodes(codes, languageNames)),
+	})
+}
+
+func checkPlace044(rec marc21.Record, rd RecordDesc) (l []Issue) {
+
+	place, ok := firstByKey(rd, "008.place_of_publication_production_or_execution")
+	if !ok || !isCoded(place) {
+		return l
+	}
+
+	codes := subfieldValues(rec, "044", "a")
+	code := strings.TrimSpace(place.Code)
+	if len(codes) == 0 || code == "vp" {
+		return l
+	}
+
+	for _, c := range codes {
+		if c == code {
+			return l
+		}
+	}
+
+	return append(l, Issue{
+		Tag:       "008",
+		Positions: place.Positions,
+		Message: fmt.Sprintf("Place of publication %s is not one of the 044 $a places %s",
+			quoteCode(code, countryNames[code]), quoteCodes(codes, countryNames)),
+	})
+}
+
+func checkDate260(rec marc21.Record, rd RecordDesc) (l []Issue) {
+
+	date1, ok := firstByKey(rd, "008.date_1")
+	if !ok || !reDateDigits.MatchString(date1.Code) || date1.Code == "uuuu" {
+		return l
+	}
+
+	dt, _ := firstByKey(rd, "008.type_of_date_publication_status")
+	switch dt.Code {
+	case "b", "n", "|", "":
+		return l
+	}
+
+	// Prefer the 264 publication statement, fall back to the 260
+	tag := "264"
+	var dates []string
+	for _, df := range rec.GetDatafields("264") {
+		if df.GetInd2() != "1" {
+			continue
+		}
+		for _, sf := range df.GetSubfields("c") {
+			dates = append(dates, sf.GetText())
+		}
+	}
+	if len(dates) == 0 {
+		tag = "260"
+		dates = subfieldValues(rec, "260", "c")
+	}
+
+	var years []string
+	for _, d := range dates {
+		years = append(years, reYear.FindAllString(d, -1)...)
+	}
+	if len(years) == 0 {
+		return l
+	}
+
+	// Date 2 may also appear in the 260/264 (production date,
+	// original date, copyright date, end of a range)
+	var date2 string
+	switch dt.Code {
+	case "i", "k", "m", "p", "q", "r", "t":
+		if d, ok := firstByKey(rd, "008.date_2"); ok && reDateDigits.MatchString(d.Code) {
+			date2 = d.Code
+		}
+	}
+
+	for _, y := range years {
+		if datesAgree(date1.Code, y) || (date2 != "" && datesAgree(date2, y)) {
+			return l
+		}
+		// Ranges
+		if date2 != "" && dt.Code != "p" && dt.Code != "r" && dt.Code != "t" &&
+			strings.Replace(date1.Code, "u", "0", -1) <= y && y <= strings.Replace(date2, "u", "9", -1) {
+			return l
+		}
+	}
+
+	return append(l, Issue{
+		Tag:       "008",
+		Positions: date1.Positions,
+		Message: fmt.Sprintf("Date 1 %q (Type of date %s) does not agree with %s $c %s",
+			date1.Code, quoteCode(dt.Code, dt.Label), tag, quoteCodes(dates, nil)),
+	})
+}
+
+// datesAgree determines if an 008 date (which may contain "u" for
+// unknown digits) agrees with a year
+func datesAgree(d, year string) bool {
+	for i := 0; i < 4; i++ {
+		if d[i] != 'u' && d[i] != year[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func checkIndexNote(rec marc21.Record, rd RecordDesc) (l []Issue) {
+
+	index, ok := firstByKey(rd, "008.bk.index")
+	if !ok || !isCoded(index) {
+		return l
+	}
+
+	var notes []string
+	for _, v := range subfieldValues(rec, "500,504", "a") {
+		if reIndexNote.MatchString(v) {
+			notes = append(notes, v)
+		}
+	}
+
+	switch {
+	case index.Code == "0" && len(notes) > 0:
+		l = append(l, Issue{
+			Tag:       "008",
+			Positions: index.Positions,
+			Message:   fmt.Sprintf("Index %s but a note mentions an index: %q", quoteCode(index.Code, index.Label), notes[0]),
+		})
+	case index.Code == "1" && len(notes) == 0:
+		l = append(l, Issue{
+			Tag:       "008",
+			Positions: index.Positions,
+			Message:   fmt.Sprintf("Index %s but no 500 or 504 note mentions an index", quoteCode(index.Code, index.Label)),
+		})
+	}
+
+	return l
+}
+
+func checkIllustrations300(rec marc21.Record, rd RecordDesc) (l []Issue) {
+
+	ill := elementsByKey(rd, "008.bk.illustrations")
+	if len(ill) == 0 {
+		return l
+	}
+
+	var codes []string
+	var labels []string
+	for _, e := range ill {
+		if e.Status == StatusMissing || e.Status == StatusFill {
+			return l
+		}
+		codes = append(codes, e.Code)
+		if isCoded(e) {
+			labels = append(labels, e.Label)
+		}
+	}
+	code := strings.Join(codes, "")
+	desc := quoteCode(code, strings.Join(labels, ", "))
+
+	b := subfieldValues(rec, "300", "b")
+
+	switch {
+	case len(labels) == 0 && len(b) > 0:
+		l = append(l, Issue{
+			Tag:       "008",
+			Positions: positions(ill[0].Offset, len(ill)),
+			Message:   fmt.Sprintf("Illustrations %s (none) but 300 $b is %s", desc, quoteCodes(b, nil)),
+		})
+	case len(labels) > 0 && len(b) == 0 && len(rec.GetDatafields("300")) > 0:
+		l = append(l, Issue{
+			Tag:       "008",
+			Positions: positions(ill[0].Offset, len(ill)),
+			Message:   fmt.Sprintf("Illustrations %s but 300 has no $b", desc),
+		})
+	}
+
+	return l
+}
+
+func checkConference111(rec marc21.Record, rd RecordDesc) (l []Issue) {
+
+	var conf ElementDesc
+	var ok bool
+	for _, m := range []string{"bk", "cr"} {
+		if conf, ok = firstByKey(rd, "008."+m+".conference_publication"); ok {
+			break
+		}
+	}
+	if !ok || !isCoded(conf) {
+		return l
+	}
+
+	meetings := len(rec.GetDatafields("111,711"))
+
+	switch {
+	case conf.Code == "0" && meetings > 0:
+		l = append(l, Issue{
+			Tag:       "008",
+			Positions: conf.Positions,
+			Message:   fmt.Sprintf("Conference publication %s but the record has a 111 or 711 meeting name", quoteCode(conf.Code, conf.Label)),
+		})
+	case conf.Code == "1" && meetings == 0:
+		l = append(l, Issue{
+			Tag:       "008",
+			Positions: conf.Positions,
+			Message:   fmt.Sprintf("Conference publication %s but the record has no 111 or 711 meeting name", quoteCode(conf.Code, conf.Label)),
+		})
+	}
+
+	return l
+}
+
+func checkThesis502(rec marc21.Record, rd RecordDesc) (l []Issue) {
+
+	noc := elementsByKey(rd, "008.bk.nature_of_contents")
+	if len(noc) == 0 {
+		return l
+	}
+
+	var codes []string
+	var thesis bool
+	for _, e := range noc {
+		if e.Status == StatusMissing || e.Status == StatusFill {
+			return l
+		}
+		codes = append(codes, e.Code)
+		if e.Code == "m" {
+			thesis = true
+		}
+	}
+	code := strings.Join(codes, "")
+
+	has502 := len(rec.GetDatafields("502")) > 0
+
+	switch {
+	case thesis && !has502:
+		l = append(l, Issue{
+			Tag:       "008",
+			Positions: positions(noc[0].Offset, len(noc)),
+			Message:   fmt.Sprintf("Nature of contents %s includes \"m\" (Theses) but the record has no 502 dissertation note", quoteCode(code, "")),
+		})
+	case !thesis && has502:
+		l = append(l, Issue{
+			Tag:       "008",
+			Positions: positions(noc[0].Offset, len(noc)),
+			Message:   fmt.Sprintf("Nature of contents %s does not include \"m\" (Theses) but the record has a 502 dissertation note", quoteCode(code, "")),
+		})
+	}
+
+	return l
+}
+
+func checkCatalogingForm040(rec marc21.Record, rd RecordDesc) (l []Issue) {
+
+	form, ok := firstByKey(rd, "ldr.descriptive_cataloging_form")
+	if !ok || form.Status == StatusMissing || rec.RecordFormat() != marc21.Bibliography {
+		return l
+	}
+
+	var rda bool
+	conventions := subfieldValues(rec, "040", "e")
+	for _, c := range conventions {
+		if strings.EqualFold(c, "rda") {
+			rda = true
+		}
+	}
+
+	if rda && form.Code != "i" && form.Code != "c" {
+		l = append(l, Issue{
+			Tag:       "LDR",
+			Positions: form.Positions,
+			Message: fmt.Sprintf("Descriptive cataloging form %s but 040 $e is %s",
+				quoteCode(form.Code, form.Label), quoteCodes(conventions, nil)),
+		})
+	}
+
+	return l
+}
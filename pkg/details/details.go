@@ -7,6 +7,8 @@
 
 package details
 
+import "strings"
+
 // CodeValue contains a code and it's corresponding descriptive label
 // for a leader or controlfield entry.
 type CodeValue struct {
@@ -50,3 +52,53 @@ func codeLookup(codeList map[string]string, b string, i, w int) (code, label str
 
 	return code, label
 }
+
+// elementName strips the "(offset/width) " prefix from a description
+// key and returns the name of the element.
+func elementName(k string) string {
+
+	if strings.HasPrefix(k, "(") {
+		if i := strings.Index(k, ") "); i > 0 {
+			return k[i+2:]
+		}
+	}
+
+	return k
+}
+
+// get returns the leader element that has the specified name
+func (ldr LdrDesc) get(name string) (cv CodeValue, ok bool) {
+
+	for k, v := range ldr {
+		if elementName(k) == name {
+			return v, true
+		}
+	}
+
+	return cv, false
+}
+
+// first returns the first value of the control field element that has
+// the specified name
+func (mc Cf008Desc) first(name string) (cv CodeValue, ok bool) {
+
+	l := mc.all(name)
+	if len(l) > 0 {
+		return l[0], true
+	}
+
+	return cv, false
+}
+
+// all returns all values of the control field element that has the
+// specified name
+func (mc Cf008Desc) all(name string) (l []CodeValue) {
+
+	for k, v := range mc {
+		if elementName(k) == name {
+			l = append(l, v...)
+		}
+	}
+
+	return l
+}
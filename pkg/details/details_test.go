@@ -0,0 +1,34 @@
+// Copyright 2017-2018 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package details
+
+import (
+	"strings"
+
+	"github.com/gsiems/go-marc21/pkg/marc21"
+)
+
+// Fixed field values used by the tests
+const (
+	testBookLeader = "00428cam a2200181 i 4500"
+	testBook008    = "980101s1998    nyua   jd     001 1 eng d"
+)
+
+// testRecord returns a record having the leader and the control fields,
+// given as "tag text" (for example "008 980101s1998...")
+func testRecord(ldr string, cfs ...string) marc21.Record {
+
+	rec := marc21.Record{Leader: marc21.Leader{Text: ldr}}
+	for _, cf := range cfs {
+		p := strings.SplitN(cf, " ", 2)
+		text := ""
+		if len(p) > 1 {
+			text = p[1]
+		}
+		rec.Controlfields = append(rec.Controlfields, &marc21.Controlfield{Tag: p[0], Text: text})
+	}
+
+	return rec
+}
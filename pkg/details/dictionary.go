@@ -0,0 +1,229 @@
+// Copyright 2017-2018 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package details
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gsiems/go-marc21/pkg/marc21"
+)
+
+/*
+The dictionary functions list the leader and control field definitions
+that are generated from the MARC21 documentation (see leader-auto.go
+and controlfield-auto.go) for use by applications, for example to fill
+the drop-downs of a record editor or to generate documentation:
+
+	for _, format := range details.Formats() {
+		for _, tag := range details.Tags(format) {
+			for _, m := range details.Materials(format, tag) {
+				fd, _ := details.GetFieldDef(format, tag, m.Code)
+				for _, e := range fd.Elements {
+					for _, c := range e.CodeList() {
+						...
+*/
+
+// MaterialDef identifies one of the definitions of a field. Code is the
+// value to pass to GetFieldDef: the material type for the bibliography
+// 008 ("BK", "MP", etc.), the 006/00 Form of material code for the 006,
+// the 007/00 Category of material code for the 007 and blank for fields
+// that have a single definition.
+type MaterialDef struct {
+	Code     string `json:"code"`
+	Material string `json:"material,omitempty"`
+	Name     string `json:"name"`
+}
+
+// Code contains a code and its label
+type Code struct {
+	Code     string `json:"code"`
+	Label    string `json:"label"`
+	Obsolete bool   `json:"obsolete,omitempty"`
+}
+
+// Formats returns the record formats (marc21.Bibliography,
+// marc21.Holdings, etc.) that have definitions
+func Formats() []int {
+
+	var l []int
+	for format := range ldrElements {
+		l = append(l, format)
+	}
+	sort.Ints(l)
+
+	return l
+}
+
+// FormatName returns the name of a record format ("Bibliography",
+// "Holdings", etc.)
+func FormatName(format int) string {
+	return formatNames[format]
+}
+
+// Tags returns the tags ("LDR", "006", "007", "008") that have
+// definitions for the specified record format
+func Tags(format int) []string {
+
+	if _, ok := ldrElements[format]; !ok {
+		return nil
+	}
+
+	l := []string{"LDR"}
+	if format == marc21.Bibliography {
+		l = append(l, "006")
+	}
+	if len(Materials(format, "007")) > 0 {
+		l = append(l, "007")
+	}
+	if len(Materials(format, "008")) > 0 {
+		l = append(l, "008")
+	}
+
+	return l
+}
+
+// Materials returns the definitions of a field for the specified record
+// format ordered by code
+func Materials(format int, tag string) (l []MaterialDef) {
+
+	switch tag {
+	case "LDR":
+		if fd, ok := LeaderDef(format); ok {
+			l = append(l, MaterialDef{Name: fd.Name})
+		}
+
+	case "006":
+		if format != marc21.Bibliography {
+			return nil
+		}
+		for code := range bibliography006Materials {
+			fd, _ := Cf006Def(code)
+			l = append(l, MaterialDef{Code: code, Material: fd.Material, Name: bibliography006FormOfMaterial[code]})
+		}
+
+	case "007":
+		var m map[string]cf007Material
+		switch format {
+		case marc21.Bibliography:
+			m = bibliography007Materials
+		case marc21.Holdings:
+			m = holdings007Materials
+		case marc21.Community:
+			fd, _ := Cf007Def(format, "")
+			l = append(l, MaterialDef{Name: fd.Name})
+		}
+		for code, cm := range m {
+			l = append(l, MaterialDef{Code: code, Material: cm.material, Name: cm.name})
+		}
+
+	case "008":
+		if format == marc21.Bibliography {
+			for code, bm := range bibliographyMaterials {
+				l = append(l, MaterialDef{Code: code, Material: code, Name: bm.name})
+			}
+		} else if fd, ok := Cf008Def(format, ""); ok {
+			l = append(l, MaterialDef{Name: fd.Name})
+		}
+	}
+
+	sort.Slice(l, func(i, j int) bool { return l[i].Code < l[j].Code })
+
+	return l
+}
+
+// GetFieldDef returns the definition of a field for the specified record
+// format, tag and code (see MaterialDef)
+func GetFieldDef(format int, tag, code string) (fd FieldDef, ok bool) {
+
+	switch tag {
+	case "LDR":
+		return LeaderDef(format)
+	case "006":
+		if format != marc21.Bibliography {
+			return fd, false
+		}
+		return Cf006Def(code)
+	case "007":
+		return Cf007Def(format, code)
+	case "008":
+		return Cf008Def(format, code)
+	}
+
+	return fd, false
+}
+
+// Code returns the 006/00 or 007/00 code that the definition is for
+func (fd FieldDef) Code() string {
+	return fd.code
+}
+
+// IsMultiCode determines if the element contains multiple codes
+// (Illustrations, Nature of contents, etc.)
+func (e ElementDef) IsMultiCode() bool {
+	return e.FnType == "multi"
+}
+
+// IsCoded determines if the element contains codes rather than data
+// (dates, numbers, etc.). Elements that may contain either, such as
+// Date 1, are coded.
+func (e ElementDef) IsCoded() bool {
+	return e.Codes != nil
+}
+
+// CodeList returns the codes defined for the element and their labels
+// ordered by code. Blanks in codes are given as is.
+func (e ElementDef) CodeList() (l []Code) {
+
+	for code, label := range e.Codes {
+		l = append(l, Code{Code: code, Label: label})
+	}
+	sort.Slice(l, func(i, j int) bool { return l[i].Code < l[j].Code })
+
+	return l
+}
+
+// ObsoleteCodeList returns the obsolete codes of the element and their
+// labels ordered by code
+func (e ElementDef) ObsoleteCodeList() (l []Code) {
+
+	for code, label := range e.Obsolete {
+		l = append(l, Code{Code: code, Label: label, Obsolete: true})
+	}
+	sort.Slice(l, func(i, j int) bool { return l[i].Code < l[j].Code })
+
+	return l
+}
+
+// FindMaterial returns the definition of a field for the specified
+// record format and code, material type ("MAP", "SOR", etc.) or name
+// ("Maps", "sound recording"). Names match case-insensitively on a
+// prefix of any word of the name.
+func FindMaterial(format int, tag, s string) (m MaterialDef, ok bool) {
+
+	l := Materials(format, tag)
+
+	for _, m := range l {
+		if m.Code == s {
+			return m, true
+		}
+	}
+
+	for _, m := range l {
+		if m.Material != "" && strings.EqualFold(m.Material, s) {
+			return m, true
+		}
+	}
+
+	s = strings.ToLower(s)
+	for _, m := range l {
+		name := strings.ToLower(m.Name)
+		if strings.HasPrefix(name, s) || strings.Contains(name, " "+s) {
+			return m, true
+		}
+	}
+
+	return m, false
+}
@@ -0,0 +1,312 @@
+// Copyright 2017-2018 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package details
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gsiems/go-marc21/pkg/marc21"
+)
+
+/*
+Diffing compares the leader and control fields of two records (for
+example, a record before and after an overlay) by decoded element rather
+than by character:
+
+	LDR/17 Encoding level: 7 Minimal level -> blank Full level
+	008/23 Form of item: blank None of the following -> o Online
+
+006 and 007 fields are paired by their 006/00 (Form of material) or
+007/00 (Category of material) code, in the order that they occur, so
+that fields which are added or removed are reported as such rather than
+as changes to the fields that follow them. Control fields that have no
+element definitions (001, 003, 005, etc.) are compared as text. The
+leader record length and base address of data are not compared as
+they change with any change to the variable fields.
+*/
+
+const (
+	// ChangeAdded indicates that the field or element is only in the
+	// new record
+	ChangeAdded = "added"
+	// ChangeRemoved indicates that the field or element is only in the
+	// old record
+	ChangeRemoved = "removed"
+	// ChangeModified indicates that the field or element differs
+	// between the records
+	ChangeModified = "changed"
+)
+
+// RecordDiff contains the differences between the leader and control
+// fields of two records
+type RecordDiff struct {
+	OldControlNumber string      `json:"old_control_number"`
+	NewControlNumber string      `json:"new_control_number"`
+	Fields           []FieldDiff `json:"fields,omitempty"`
+}
+
+// FieldDiff contains the differences for the leader or a single control
+// field. Occurrence is the occurrence of the field, in the new record
+// unless the field was removed, counting from 1.
+type FieldDiff struct {
+	Tag         string          `json:"tag"`
+	Occurrence  int             `json:"occurrence"`
+	Change      string          `json:"change"`
+	OldMaterial string          `json:"old_material,omitempty"`
+	NewMaterial string          `json:"new_material,omitempty"`
+	OldText     string          `json:"old_text"`
+	NewText     string          `json:"new_text"`
+	Elements    []ElementChange `json:"elements,omitempty"`
+}
+
+// ElementChange contains the old and new values of an element. Elements
+// that contain multiple codes are compared code by code with Seq
+// indicating which one.
+type ElementChange struct {
+	Key       string `json:"key"`
+	Name      string `json:"name"`
+	Positions string `json:"positions"`
+	Seq       int    `json:"seq,omitempty"`
+	Change    string `json:"change"`
+	OldCode   string `json:"old_code"`
+	OldLabel  string `json:"old_label"`
+	NewCode   string `json:"new_code"`
+	NewLabel  string `json:"new_label"`
+	offset    int
+}
+
+// DiffRecords compares the leader and control fields of two records
+func DiffRecords(oldRec, newRec marc21.Record) (d RecordDiff) {
+
+	d.OldControlNumber = oldRec.GetControlfield("001")
+	d.NewControlNumber = newRec.GetControlfield("001")
+
+	od := DescribeRecord(oldRec)
+	nd := DescribeRecord(newRec)
+
+	for _, p := range pairFields(od.Fields, nd.Fields) {
+		fdiff, changed := diffField(p)
+		if changed {
+			d.Fields = append(d.Fields, fdiff)
+		}
+	}
+
+	return d
+}
+
+// Equal determines if the leader and control fields of the records
+// are the same
+func (d RecordDiff) Equal() bool {
+	return len(d.Fields) == 0
+}
+
+// String returns the change as text, for example
+// "Encoding level: 7 Minimal level -> blank Full level" or, for
+// elements that are only in one of the records,
+// "Form of item: o Online (added)"
+func (c ElementChange) String() string {
+
+	name := c.Name
+	if c.Seq > 0 {
+		name += " " + strconv.Itoa(c.Seq)
+	}
+
+	switch c.Change {
+	case ChangeAdded:
+		return name + ": " + CodeText(c.NewCode, c.NewLabel) + " (added)"
+	case ChangeRemoved:
+		return name + ": " + CodeText(c.OldCode, c.OldLabel) + " (removed)"
+	}
+
+	return name + ": " + CodeText(c.OldCode, c.OldLabel) + " -> " + CodeText(c.NewCode, c.NewLabel)
+}
+
+// CodeText returns a code and its label as text. Blank codes are shown
+// as "blank" and missing codes as "none".
+func CodeText(code, label string) string {
+
+	var s string
+	switch {
+	case code == "":
+		s = "none"
+	case strings.TrimSpace(code) == "":
+		s = "blank"
+	default:
+		s = strings.Replace(code, " ", "#", -1)
+	}
+
+	if label != "" {
+		s += " " + label
+	}
+
+	return s
+}
+
+// computedElements are the leader elements that are set when a record
+// is written
+var computedElements = map[string]bool{
+	"ldr.logical_record_length": true,
+	"ldr.record_length":         true,
+	"ldr.base_address_of_data":  true,
+}
+
+// fieldPair is a field of the old record and the corresponding field
+// of the new record, either of which may be missing
+type fieldPair struct {
+	tag      string
+	occ      int
+	old, new *FieldDesc
+}
+
+// pairFields pairs the fields of the old and new records. Fields are
+// paired by tag, in order, and 006 and 007 fields also by their first
+// character.
+func pairFields(ol, nl []FieldDesc) (l []fieldPair) {
+
+	pairKey := func(f FieldDesc) string {
+		if f.Tag == "006" || f.Tag == "007" {
+			return f.Tag + pluckByte(f.Text, 0)
+		}
+		return f.Tag
+	}
+
+	// The unpaired old fields, by pair key
+	olds := make(map[string][]*FieldDesc)
+	for i := range ol {
+		k := pairKey(ol[i])
+		olds[k] = append(olds[k], &ol[i])
+	}
+
+	occ := make(map[string]int)
+	for i := range nl {
+		f := &nl[i]
+		occ[f.Tag]++
+		p := fieldPair{tag: f.Tag, occ: occ[f.Tag], new: f}
+		k := pairKey(*f)
+		if len(olds[k]) > 0 {
+			p.old = olds[k][0]
+			olds[k] = olds[k][1:]
+		}
+		l = append(l, p)
+	}
+
+	// Removed fields, in the order of the old record
+	oocc := make(map[string]int)
+	for i := range ol {
+		f := &ol[i]
+		oocc[f.Tag]++
+		for _, r := range olds[pairKey(*f)] {
+			if r == f {
+				l = append(l, fieldPair{tag: f.Tag, occ: oocc[f.Tag], old: f})
+			}
+		}
+	}
+
+	return l
+}
+
+// diffField compares a pair of fields
+func diffField(p fieldPair) (d FieldDiff, changed bool) {
+
+	d = FieldDiff{Tag: p.tag, Occurrence: p.occ}
+
+	var oldElements, newElements []ElementDesc
+	if p.old != nil {
+		d.OldMaterial = p.old.Material
+		d.OldText = p.old.Text
+		oldElements = p.old.Elements
+	}
+	if p.new != nil {
+		d.NewMaterial = p.new.Material
+		d.NewText = p.new.Text
+		newElements = p.new.Elements
+	}
+
+	switch {
+	case p.old == nil:
+		d.Change = ChangeAdded
+	case p.new == nil:
+		d.Change = ChangeRemoved
+	case d.OldText == d.NewText && d.OldMaterial == d.NewMaterial:
+		return d, false
+	default:
+		d.Change = ChangeModified
+	}
+
+	d.Elements = diffElements(oldElements, newElements)
+	if d.Change == ChangeModified && d.Tag == "LDR" && len(d.Elements) == 0 {
+		return d, false
+	}
+
+	return d, true
+}
+
+// diffElements compares the decoded elements of a pair of fields.
+// Elements are paired by ID and position so that, where the material of
+// the field changed, the elements that the materials have in common
+// (Target audience, Form of item, etc.) are compared and the rest are
+// reported as removed or added.
+func diffElements(ol, nl []ElementDesc) (l []ElementChange) {
+
+	elementKey := func(ed ElementDesc) string {
+		return ed.ID + "/" + ed.Positions + "/" + strconv.Itoa(ed.Seq)
+	}
+
+	olds := make(map[string]ElementDesc)
+	for _, ed := range ol {
+		olds[elementKey(ed)] = ed
+	}
+
+	paired := make(map[string]bool)
+	for _, ed := range nl {
+		if computedElements[ed.Key] {
+			continue
+		}
+		c := ElementChange{
+			Key:       ed.Key,
+			Name:      ed.Name,
+			Positions: ed.Positions,
+			Seq:       ed.Seq,
+			Change:    ChangeAdded,
+			NewCode:   ed.Code,
+			NewLabel:  ed.Label,
+			offset:    ed.Offset,
+		}
+		if o, ok := olds[elementKey(ed)]; ok {
+			paired[elementKey(ed)] = true
+			if o.Code == ed.Code {
+				continue
+			}
+			c.Change = ChangeModified
+			c.OldCode = o.Code
+			c.OldLabel = o.Label
+		}
+		l = append(l, c)
+	}
+
+	for _, ed := range ol {
+		if paired[elementKey(ed)] || computedElements[ed.Key] {
+			continue
+		}
+		l = append(l, ElementChange{
+			Key:       ed.Key,
+			Name:      ed.Name,
+			Positions: ed.Positions,
+			Seq:       ed.Seq,
+			Change:    ChangeRemoved,
+			OldCode:   ed.Code,
+			OldLabel:  ed.Label,
+			offset:    ed.Offset,
+		})
+	}
+
+	sort.SliceStable(l, func(i, j int) bool {
+		return l[i].offset < l[j].offset
+	})
+
+	return l
+}
@@ -0,0 +1,228 @@
+// Copyright 2017-2018 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package details
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gsiems/go-marc21/pkg/marc21"
+)
+
+/*
+Editing changes a single element of the leader or of a 006, 007 or
+008 of a record, leaving the rest of the field untouched. Elements are
+identified either by key or by position:
+
+	ldr.encoding_level        the leader encoding level
+	008.bk.form_of_item       the form of item of a books 008
+	008.form_of_item          the form of item of the 008, whatever the material
+	007_2.vir.color           the color of the second 007
+	008/23                    the 008 element at position 23
+	ldr/17                    the leader element at position 17
+
+The material may be omitted, in which case the definition for the
+material of the field is used. For elements that contain multiple
+codes (Illustrations, etc.) a key sets the whole element while a
+position sets the single code at that position. Where a record has
+several 006 or 007 fields containing the element the occurrence must
+be given ("006_1", "007_2", etc.).
+
+Values are given as codes or labels and are validated as for
+FieldDef.Encode.
+*/
+
+// elementRef being a parsed element key or position
+type elementRef struct {
+	tag      string
+	occ      int
+	material string
+	id       string
+	pos      int
+}
+
+// SetElement sets the value of an element of the leader or of a
+// control field of a record. The record is updated in place and is
+// also returned.
+func SetElement(rec *marc21.Record, key, value string) (*marc21.Record, error) {
+
+	ref, err := parseElementRef(key)
+	if err != nil {
+		return rec, err
+	}
+
+	format := rec.RecordFormat()
+
+	var material string
+	if format == marc21.Bibliography {
+		material, _ = rec.BibliographyMaterialType()
+	}
+
+	if ref.tag == "LDR" {
+		fd, ok := LeaderDef(format)
+		if !ok {
+			return rec, fmt.Errorf("%s: unknown record format", key)
+		}
+		text, err := fd.setElement(rec.Leader.Text, ref, value)
+		if err != nil {
+			return rec, fmt.Errorf("%s: %s", key, err)
+		}
+		rec.Leader.Text = text
+		return rec, nil
+	}
+
+	var targets []*marc21.Controlfield
+	var defs []FieldDef
+	var n int
+	for _, cf := range rec.Controlfields {
+		if cf.Tag != ref.tag {
+			continue
+		}
+		n++
+		if ref.occ > 0 && n != ref.occ {
+			continue
+		}
+		fd, ok := controlfieldDef(format, material, cf)
+		if !ok || !fd.hasElement(ref) {
+			continue
+		}
+		targets = append(targets, cf)
+		defs = append(defs, fd)
+	}
+
+	switch {
+	case len(targets) == 0:
+		return rec, fmt.Errorf("%s: no %s field has the element", key, ref.tag)
+	case len(targets) > 1:
+		return rec, fmt.Errorf("%s: %d %s fields have the element, specify which (%s_1, %s_2, etc.)", key, len(targets), ref.tag, ref.tag, ref.tag)
+	}
+
+	text, err := defs[0].setElement(targets[0].Text, ref, value)
+	if err != nil {
+		return rec, fmt.Errorf("%s: %s", key, err)
+	}
+	targets[0].Text = text
+
+	return rec, nil
+}
+
+// SetElement sets the value of an element, identified by ID, in the
+// text of the leader or control field and returns the updated text
+func (fd FieldDef) SetElement(text, id, value string) (string, error) {
+	return fd.setElement(text, elementRef{tag: fd.Tag, id: id, pos: -1}, value)
+}
+
+// setElement sets the value of the referenced element in the text of
+// the leader or control field
+func (fd FieldDef) setElement(text string, ref elementRef, value string) (string, error) {
+
+	e, ok := fd.findElement(ref)
+	if !ok {
+		return text, fmt.Errorf("the %s definition has no such element", fd.Name)
+	}
+
+	var code string
+	var err error
+	if ref.pos >= 0 && e.FnType == "multi" {
+		// A single code of a multi-code element
+		e.Offset += (ref.pos - e.Offset) / e.CodeWidth * e.CodeWidth
+		e.Width = e.CodeWidth
+		code, err = e.encodeMulti(value)
+	} else {
+		code, err = e.encode(value)
+	}
+	if err != nil {
+		return text, fmt.Errorf("%s: %s", e.Name, err)
+	}
+
+	// Pad short fields so that the element can be set
+	if len(text) < e.Offset+e.Width {
+		text += strings.Repeat(" ", e.Offset+e.Width-len(text))
+	}
+
+	return text[:e.Offset] + code + text[e.Offset+e.Width:], nil
+}
+
+// hasElement determines if the definition contains the referenced
+// element
+func (fd FieldDef) hasElement(ref elementRef) bool {
+	_, ok := fd.findElement(ref)
+	return ok
+}
+
+// findElement returns the referenced element
+func (fd FieldDef) findElement(ref elementRef) (e ElementDef, ok bool) {
+
+	if ref.material != "" && ref.material != strings.ToLower(fd.Material) {
+		return e, false
+	}
+
+	for _, e := range fd.Elements {
+		if ref.pos >= 0 {
+			if ref.pos >= e.Offset && ref.pos < e.Offset+e.Width {
+				return e, true
+			}
+			continue
+		}
+		if e.ID() == ref.id {
+			return e, true
+		}
+	}
+
+	return e, false
+}
+
+// parseElementRef parses an element key ("008.bk.form_of_item",
+// "007_2.vir.color") or position ("008/23", "ldr/17")
+func parseElementRef(key string) (ref elementRef, err error) {
+
+	ref.pos = -1
+	k := strings.ToLower(strings.TrimSpace(key))
+
+	var rest string
+	if i := strings.IndexAny(k, "./"); i > 0 {
+		ref.tag, rest = k[:i], k[i:]
+	} else {
+		return ref, fmt.Errorf("invalid element %q", key)
+	}
+
+	if i := strings.Index(ref.tag, "_"); i > 0 {
+		ref.occ, err = strconv.Atoi(ref.tag[i+1:])
+		if err != nil || ref.occ < 1 {
+			return ref, fmt.Errorf("invalid occurrence in %q", key)
+		}
+		ref.tag = ref.tag[:i]
+	}
+
+	switch ref.tag {
+	case "ldr":
+		ref.tag = "LDR"
+	case "006", "007", "008":
+	default:
+		return ref, fmt.Errorf("invalid element %q: tag must be one of ldr, 006, 007 or 008", key)
+	}
+
+	if rest[0] == '/' {
+		ref.pos, err = strconv.Atoi(rest[1:])
+		if err != nil || ref.pos < 0 {
+			return ref, fmt.Errorf("invalid position in %q", key)
+		}
+		return ref, nil
+	}
+
+	p := strings.Split(rest[1:], ".")
+	switch len(p) {
+	case 1:
+		ref.id = p[0]
+	case 2:
+		ref.material, ref.id = p[0], p[1]
+	}
+	if ref.id == "" {
+		return ref, fmt.Errorf("invalid element %q", key)
+	}
+
+	return ref, nil
+}
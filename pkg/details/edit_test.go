@@ -0,0 +1,111 @@
+// Copyright 2017-2018 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package details
+
+import (
+	"testing"
+)
+
+func TestParseElementRef(t *testing.T) {
+
+	tests := []struct {
+		key     string
+		want    elementRef
+		wantErr bool
+	}{
+		{"ldr/17", elementRef{tag: "LDR", pos: 17}, false},
+		{"LDR/17", elementRef{tag: "LDR", pos: 17}, false},
+		{"008/23", elementRef{tag: "008", pos: 23}, false},
+		{"ldr.encoding_level", elementRef{tag: "LDR", id: "encoding_level", pos: -1}, false},
+		{"008.bk.target_audience", elementRef{tag: "008", material: "bk", id: "target_audience", pos: -1}, false},
+		{"008.target_audience", elementRef{tag: "008", id: "target_audience", pos: -1}, false},
+		{"007_2.vir.color", elementRef{tag: "007", occ: 2, material: "vir", id: "color", pos: -1}, false},
+		{"006_1/00", elementRef{tag: "006", occ: 1, pos: 0}, false},
+		{"ldr", elementRef{}, true},
+		{"245.a", elementRef{}, true},
+		{"008/", elementRef{}, true},
+		{"008/-1", elementRef{}, true},
+		{"008/x", elementRef{}, true},
+		{"008.", elementRef{}, true},
+		{"008.bk.target_audience.x", elementRef{}, true},
+		{"007_0.vir.color", elementRef{}, true},
+		{"007_x.vir.color", elementRef{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseElementRef(tt.key)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseElementRef(%q) error = %v, want error %v", tt.key, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("parseElementRef(%q) = %+v, want %+v", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestSetElement(t *testing.T) {
+
+	cr007 := "cr |||||||||||"
+	vd007 := "vd cvaizq"
+
+	tests := []struct {
+		name    string
+		key     string
+		value   string
+		tag     string
+		occ     int
+		want    string
+		wantErr bool
+	}{
+		{"leader by key", "ldr.encoding_level", "7", "LDR", 1, testBookLeader[:17] + "7" + testBookLeader[18:], false},
+		{"leader by position", "ldr/17", "7", "LDR", 1, testBookLeader[:17] + "7" + testBookLeader[18:], false},
+		{"leader by label", "ldr/17", "Full level", "LDR", 1, testBookLeader, false},
+		{"008 by key", "008.bk.form_of_item", "o", "008", 1, testBook008[:23] + "o" + testBook008[24:], false},
+		{"008 by position", "008/23", "o", "008", 1, testBook008[:23] + "o" + testBook008[24:], false},
+		{"008 with material", "008.bk.target_audience", "Adult", "008", 1, testBook008[:22] + "e" + testBook008[23:], false},
+		{"008 without material", "008.target_audience", "Adult", "008", 1, testBook008[:22] + "e" + testBook008[23:], false},
+		{"008 multi-code position", "008/19", "b", "008", 1, testBook008[:19] + "b" + testBook008[20:], false},
+		{"007 occurrence", "007_2.vir.color", "b", "007", 2, vd007[:3] + "b" + vd007[4:], false},
+		{"007 by material", "007.vir.color", "b", "007", 2, vd007[:3] + "b" + vd007[4:], false},
+		{"language", "008.language", "French", "008", 1, testBook008[:35] + "fre" + testBook008[38:], false},
+		{"place", "008.place_of_publication_production_or_execution", "fr", "008", 1, testBook008[:15] + "fr " + testBook008[18:], false},
+		{"unlisted language", "008.language", "mao", "008", 1, testBook008[:35] + "mao" + testBook008[38:], false},
+		{"malformed language", "008.language", "e1g", "", 0, "", true},
+		{"malformed place", "008.place_of_publication_production_or_execution", "NY", "", 0, "", true},
+		{"undefined code", "008.bk.form_of_item", "x", "", 0, "", true},
+		{"leader position out of range", "ldr/24", "a", "", 0, "", true},
+		{"008 position out of range", "008/40", "a", "", 0, "", true},
+		{"wide leader code", "ldr/17", "77", "", 0, "", true},
+		{"wide 008 code", "008.bk.target_audience", "jj", "", 0, "", true},
+		{"short language", "008.language", "fr", "", 0, "", true},
+		{"missing occurrence", "007_3.vir.color", "b", "", 0, "", true},
+		{"ambiguous occurrence", "007.color", "b", "", 0, "", true},
+		{"occurrence of another material", "007_1.vir.color", "b", "", 0, "", true},
+		{"wrong material", "008.mp.projection", "aa", "", 0, "", true},
+		{"no such field", "006.form_of_material", "a", "", 0, "", true},
+		{"invalid key", "008", "a", "", 0, "", true},
+	}
+
+	for _, tt := range tests {
+		rec := testRecord(testBookLeader, "007 "+cr007, "007 "+vd007, "008 "+testBook008)
+		_, err := SetElement(&rec, tt.key, tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: SetElement error = %v, want error %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+
+		got := rec.Leader.Text
+		if tt.tag != "LDR" {
+			got = rec.GetControlfields(tt.tag)[tt.occ-1].Text
+		}
+		if got != tt.want {
+			t.Errorf("%s: SetElement %s = %q, want %q", tt.name, tt.tag, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,539 @@
+// Copyright 2017-2018 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package details
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gsiems/go-marc21/pkg/marc21"
+)
+
+// The sole purpose of this file is to provide an interface to the
+// element lists in the leader-auto.go and controlfield-auto.go files.
+
+const (
+	// StatusDefined indicates that the code is defined for the element
+	StatusDefined = "defined"
+	// StatusUndefined indicates that the code is not defined for the element
+	StatusUndefined = "undefined"
+	// StatusObsolete indicates that the code is no longer defined for
+	// the element
+	StatusObsolete = "obsolete"
+	// StatusFill indicates that the element contains fill characters
+	StatusFill = "fill"
+	// StatusMissing indicates that the field is too short to contain the element
+	StatusMissing = "missing"
+	// StatusData indicates that the element contains data (dates,
+	// numbers, etc.) rather than codes
+	StatusData = "data"
+)
+
+// ElementDef defines a single leader or control field element.
+// Obsolete contains the codes that are no longer defined but that may
+// be found in older records (none of the current generated definitions
+// have any).
+type ElementDef struct {
+	Name       string
+	Offset     int
+	Width      int
+	CodeWidth  int
+	FnType     string
+	Codes      map[string]string
+	Obsolete   map[string]string
+	RangeLabel string
+}
+
+// FieldDef defines the elements of the leader, or of a control field,
+// for a record format and, where applicable, a material type
+type FieldDef struct {
+	Format   string
+	Tag      string
+	Material string
+	Name     string
+	Elements []ElementDef
+	// code is the 006/00 or 007/00 code that the definition is for
+	code string
+}
+
+// RecordDesc contains the decoded leader and control fields of a record
+type RecordDesc struct {
+	ControlNumber string      `json:"control_number"`
+	Format        string      `json:"format"`
+	Material      string      `json:"material,omitempty"`
+	Fields        []FieldDesc `json:"fields"`
+}
+
+// FieldDesc contains the decoded elements of the leader or of a single
+// control field
+type FieldDesc struct {
+	Tag      string        `json:"tag"`
+	Material string        `json:"material,omitempty"`
+	Text     string        `json:"text"`
+	Elements []ElementDesc `json:"elements,omitempty"`
+}
+
+// ElementDesc contains the decoded value of a single element. Elements
+// that contain multiple codes (Illustrations, Nature of contents, etc.)
+// result in one ElementDesc per code with Seq indicating which one.
+type ElementDesc struct {
+	Key       string `json:"key"`
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Positions string `json:"positions"`
+	Offset    int    `json:"offset"`
+	Width     int    `json:"width"`
+	Seq       int    `json:"seq,omitempty"`
+	Code      string `json:"code"`
+	Label     string `json:"label"`
+	Status    string `json:"status"`
+}
+
+var formatNames = map[int]string{
+	marc21.Bibliography:   "Bibliography",
+	marc21.Holdings:       "Holdings",
+	marc21.Authority:      "Authority",
+	marc21.Classification: "Classification",
+	marc21.Community:      "Community",
+}
+
+var ldrElements = map[int][]ElementDef{
+	marc21.Bibliography:   bibliographyLdrElements,
+	marc21.Holdings:       holdingsLdrElements,
+	marc21.Authority:      authorityLdrElements,
+	marc21.Classification: classificationLdrElements,
+	marc21.Community:      communityLdrElements,
+}
+
+var cf008Elements = map[int][]ElementDef{
+	marc21.Holdings:       holdings008Elements,
+	marc21.Authority:      authority008Elements,
+	marc21.Classification: classification008Elements,
+	marc21.Community:      community008Elements,
+}
+
+// bibliographyMaterials contains the names and material specific 008
+// elements for the bibliography material types
+var bibliographyMaterials = map[string]struct {
+	name     string
+	elements []ElementDef
+}{
+	"BK": {"Books", bibliography008BKElements},
+	"CF": {"Computer files", bibliography008CFElements},
+	"MP": {"Maps", bibliography008MPElements},
+	"MU": {"Music", bibliography008MUElements},
+	"CR": {"Continuing resources", bibliography008CRElements},
+	"VM": {"Visual materials", bibliography008VMElements},
+	"MX": {"Mixed materials", bibliography008MXElements},
+}
+
+// bibliography006Materials maps the 006/00 (Form of material) codes to
+// the bibliography material types
+var bibliography006Materials = map[string]string{
+	"a": "BK",
+	"t": "BK",
+	"m": "CF",
+	"e": "MP",
+	"f": "MP",
+	"c": "MU",
+	"d": "MU",
+	"i": "MU",
+	"j": "MU",
+	"s": "CR",
+	"g": "VM",
+	"k": "VM",
+	"o": "VM",
+	"r": "VM",
+	"p": "MX",
+}
+
+// cf007Material contains the name and elements for a 007 category of
+// material
+type cf007Material struct {
+	material string
+	name     string
+	elements []ElementDef
+}
+
+var bibliography007Materials = map[string]cf007Material{
+	"a": {"MAP", "Map", bibliography007MAPElements},
+	"c": {"ELR", "Electronic resource", bibliography007ELRElements},
+	"d": {"GLB", "Globe", bibliography007GLBElements},
+	"f": {"TAM", "Tactile material", bibliography007TAMElements},
+	"g": {"PRG", "Projected graphic", bibliography007PRGElements},
+	"h": {"MIC", "Microform", bibliography007MICElements},
+	"k": {"NPG", "Nonprojected graphic", bibliography007NPGElements},
+	"m": {"MOP", "Motion picture", bibliography007MOPElements},
+	"o": {"KIT", "Kit", bibliography007KITElements},
+	"q": {"NMU", "Notated music", bibliography007NMUElements},
+	"r": {"RSI", "Remote-sensing image", bibliography007RSIElements},
+	"s": {"SOR", "Sound recording", bibliography007SORElements},
+	"t": {"TXT", "Text", bibliography007TXTElements},
+	"v": {"VIR", "Videorecording", bibliography007VIRElements},
+	"z": {"UNS", "Unspecified", bibliography007UNSElements},
+}
+
+var holdings007Materials = map[string]cf007Material{
+	"a": {"MAP", "Map", holdings007MAPElements},
+	"c": {"ELR", "Electronic resource", holdings007ELRElements},
+	"d": {"GLB", "Globe", holdings007GLBElements},
+	"f": {"TAM", "Tactile material", holdings007TAMElements},
+	"g": {"PRG", "Projected graphic", holdings007PRGElements},
+	"h": {"MIC", "Microform", holdings007MICElements},
+	"k": {"NPG", "Nonprojected graphic", holdings007NPGElements},
+	"m": {"MOP", "Motion picture", holdings007MOPElements},
+	"o": {"KIT", "Kit", holdings007KITElements},
+	"q": {"NMU", "Notated music", holdings007NMUElements},
+	"r": {"RSI", "Remote-sensing image", holdings007RSIElements},
+	"s": {"SOR", "Sound recording", holdings007SORElements},
+	"t": {"TXT", "Text", holdings007TXTElements},
+	"v": {"VIR", "Videorecording", holdings007VIRElements},
+	"z": {"UNS", "Unspecified", holdings007UNSElements},
+}
+
+// ID returns the machine identifier for the element. This is the
+// element name in lower case with the words separated by underscores.
+// As there may be more than one undefined element for a field, the
+// identifiers for undefined elements are suffixed with the offset.
+func (e ElementDef) ID() string {
+
+	var b strings.Builder
+	sep := false
+	for _, r := range strings.ToLower(e.Name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if sep && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(r)
+			sep = false
+		} else {
+			sep = true
+		}
+	}
+
+	if strings.HasPrefix(e.Name, "Undefined") {
+		fmt.Fprintf(&b, "_%02d", e.Offset)
+	}
+
+	return b.String()
+}
+
+// Positions returns the character position(s) of the element as
+// either a single position ("06") or a range of positions ("18-21")
+func (e ElementDef) Positions() string {
+	return positions(e.Offset, e.Width)
+}
+
+func positions(offset, width int) string {
+	if width <= 1 {
+		return fmt.Sprintf("%02d", offset)
+	}
+	return fmt.Sprintf("%02d-%02d", offset, offset+width-1)
+}
+
+// LeaderDef returns the leader definition for the specified record
+// format (marc21.Bibliography, marc21.Holdings, etc.)
+func LeaderDef(format int) (fd FieldDef, ok bool) {
+
+	l, ok := ldrElements[format]
+	if !ok {
+		return fd, false
+	}
+
+	fd = FieldDef{Format: formatNames[format], Tag: "LDR", Name: "Leader", Elements: l}
+	return fd, true
+}
+
+// Cf008Def returns the 008 definition for the specified record format.
+// For bibliography records the material is one of "BK", "CF", "MP",
+// "MU", "CR", "VM" or "MX" and the definition contains both the
+// elements for all materials and the material specific elements.
+func Cf008Def(format int, material string) (fd FieldDef, ok bool) {
+
+	if format != marc21.Bibliography {
+		l, ok := cf008Elements[format]
+		if !ok {
+			return fd, false
+		}
+		fd = FieldDef{Format: formatNames[format], Tag: "008", Name: "Fixed-length data elements", Elements: l}
+		return fd, true
+	}
+
+	bm, ok := bibliographyMaterials[material]
+	if !ok {
+		return fd, false
+	}
+
+	var l []ElementDef
+	for _, e := range bibliography008Elements {
+		if e.Offset < 18 {
+			l = append(l, e)
+		}
+	}
+	l = append(l, bm.elements...)
+	for _, e := range bibliography008Elements {
+		if e.Offset >= 18 {
+			l = append(l, e)
+		}
+	}
+
+	fd = FieldDef{Format: formatNames[format], Tag: "008", Material: material, Name: bm.name, Elements: l}
+	return fd, true
+}
+
+// Cf006Def returns the bibliography 006 definition for the specified
+// 006/00 (Form of material) code. The element offsets are the 006
+// offsets.
+func Cf006Def(formOfMaterial string) (fd FieldDef, ok bool) {
+
+	material, ok := bibliography006Materials[formOfMaterial]
+	if !ok {
+		return fd, false
+	}
+	bm := bibliographyMaterials[material]
+
+	// Ref: http://www.loc.gov/marc/bibliographic/bd006.html
+	// "... the codes defined for character positions 01-17 will be
+	// the same as those defined in the corresponding field 008,
+	// character positions 18-34."
+	l := []ElementDef{
+		{Name: "Form of material", Offset: 0, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliography006FormOfMaterial},
+	}
+	for _, e := range bm.elements {
+		e.Offset -= 17
+		l = append(l, e)
+	}
+
+	fd = FieldDef{Format: formatNames[marc21.Bibliography], Tag: "006", Material: material, Name: bm.name, Elements: l, code: formOfMaterial}
+	return fd, true
+}
+
+// Cf007Def returns the 007 definition for the specified record format
+// and 007/00 (Category of material) code
+func Cf007Def(format int, category string) (fd FieldDef, ok bool) {
+
+	var m cf007Material
+
+	switch format {
+	case marc21.Bibliography:
+		m, ok = bibliography007Materials[category]
+	case marc21.Holdings:
+		m, ok = holdings007Materials[category]
+	case marc21.Community:
+		m, ok = cf007Material{"", "Physical description", community007Elements}, true
+	}
+	if !ok {
+		return fd, false
+	}
+
+	fd = FieldDef{Format: formatNames[format], Tag: "007", Material: m.material, Name: m.name, Elements: m.elements, code: category}
+	return fd, true
+}
+
+// Length returns the length of the leader or control field as
+// determined by the last element of the definition
+func (fd FieldDef) Length() (n int) {
+	for _, e := range fd.Elements {
+		if e.Offset+e.Width > n {
+			n = e.Offset + e.Width
+		}
+	}
+	return n
+}
+
+// Decode decodes the text of the leader or control field using the
+// field definition
+func (fd FieldDef) Decode(s string) (l []ElementDesc) {
+
+	for _, e := range fd.Elements {
+		for _, ed := range e.decode(s) {
+			ed.Key = fd.Key(e)
+			l = append(l, ed)
+		}
+	}
+
+	return l
+}
+
+// Key returns the key that identifies an element of the field. The key
+// consists of the lower case tag ("ldr", "006", "007" or "008"), the
+// lower case material type for elements that are material specific,
+// and the element ID. For example:
+//
+//	ldr.encoding_level
+//	008.date_1
+//	008.bk.target_audience
+//	007.category_of_material
+//	007.vir.videorecording_format
+func (fd FieldDef) Key(e ElementDef) string {
+
+	k := strings.ToLower(fd.Tag)
+
+	if fd.Material != "" {
+		common := false
+		switch fd.Tag {
+		case "006", "007":
+			common = e.Offset == 0
+		case "008":
+			common = e.Offset < 18 || e.Offset > 34
+		}
+		if !common {
+			k += "." + strings.ToLower(fd.Material)
+		}
+	}
+
+	return k + "." + e.ID()
+}
+
+// decode decodes the element value(s) from the leader or control
+// field text
+func (e ElementDef) decode(s string) (l []ElementDesc) {
+
+	ed := ElementDesc{ID: e.ID(), Name: e.Name, Positions: e.Positions(), Offset: e.Offset, Width: e.Width}
+
+	switch e.FnType {
+	case "multi":
+		seq := 1
+		for i := e.Offset; i < e.Offset+e.Width; i += e.CodeWidth {
+			md := ed
+			md.Offset = i
+			md.Width = e.CodeWidth
+			md.Positions = positions(i, e.CodeWidth)
+			md.Seq = seq
+			md.Code, md.Label = e.lookup(s, i, e.CodeWidth)
+			md.Status = e.status(md.Code, md.Label)
+			l = append(l, md)
+			seq++
+		}
+		return l
+
+	case "hybrid":
+		w := e.CodeWidth
+		if w > e.Width {
+			w = e.Width
+		}
+		ed.Code, ed.Label = e.lookup(s, e.Offset, w)
+		if ed.Code != "" && ed.Label == "" && strings.Trim(ed.Code, "|") != "" {
+			ed.Label = e.RangeLabel
+		}
+
+	case "hybrid-date":
+		ed.Code = pluckBytes(s, e.Offset, e.Width)
+		if ed.Code != "" {
+			c := pluckByte(s, e.Offset)
+			ed.Label = e.Codes[c]
+			if _, ok := e.Obsolete[c]; ok && ed.Label == "" {
+				ed.Label = e.Obsolete[c]
+				ed.Status = StatusObsolete
+				return append(l, ed)
+			}
+			if ed.Label == "" {
+				ed.Label = "Date"
+			}
+		}
+
+	case "lookup":
+		ed.Code, ed.Label = e.lookup(s, e.Offset, e.Width)
+
+	default:
+		ed.Code = pluckBytes(s, e.Offset, e.Width)
+	}
+
+	ed.Status = e.status(ed.Code, ed.Label)
+
+	return append(l, ed)
+}
+
+// lookup returns the code at the specified offset and its label. The
+// labels of obsolete codes are also returned.
+func (e ElementDef) lookup(s string, offset, width int) (code, label string) {
+
+	code, label = codeLookup(e.Codes, s, offset, width)
+	if label == "" {
+		label = e.Obsolete[code]
+	}
+
+	return code, label
+}
+
+// status determines the status of a decoded code/label
+func (e ElementDef) status(code, label string) string {
+
+	_, defined := e.Codes[code]
+	_, obsolete := e.Obsolete[code]
+
+	switch {
+	case code == "":
+		return StatusMissing
+	case strings.Trim(code, "|") == "":
+		return StatusFill
+	case e.Codes == nil:
+		return StatusData
+	case obsolete && !defined:
+		return StatusObsolete
+	case label == "":
+		return StatusUndefined
+	}
+
+	return StatusDefined
+}
+
+// DescribeRecord decodes the leader and control fields of a record
+// using the element definitions. Unlike the Parse* functions the
+// elements are returned in field order and, for 006 fields, the
+// offsets are the 006 offsets.
+func DescribeRecord(rec marc21.Record) (rd RecordDesc) {
+
+	format := rec.RecordFormat()
+
+	rd.ControlNumber = rec.GetControlfield("001")
+	rd.Format = formatNames[format]
+	if format == marc21.Bibliography {
+		rd.Material, _ = rec.BibliographyMaterialType()
+	}
+
+	ldr := FieldDesc{Tag: "LDR", Text: rec.Leader.Text}
+	if fd, ok := LeaderDef(format); ok {
+		ldr.Elements = fd.Decode(rec.Leader.Text)
+	}
+	rd.Fields = append(rd.Fields, ldr)
+
+	for _, cf := range rec.Controlfields {
+		rd.Fields = append(rd.Fields, describeControlfield(format, rd.Material, cf))
+	}
+
+	return rd
+}
+
+// describeControlfield decodes a single control field
+func describeControlfield(format int, material string, cf *marc21.Controlfield) (d FieldDesc) {
+
+	d = FieldDesc{Tag: cf.Tag, Text: cf.Text}
+
+	if fd, ok := controlfieldDef(format, material, cf); ok {
+		d.Material = fd.Material
+		d.Elements = fd.Decode(cf.Text)
+	}
+
+	return d
+}
+
+// controlfieldDef returns the definition used to decode a control
+// field of a record having the specified format and material
+func controlfieldDef(format int, material string, cf *marc21.Controlfield) (fd FieldDef, ok bool) {
+
+	switch cf.Tag {
+	case "006":
+		if format == marc21.Bibliography {
+			return Cf006Def(pluckByte(cf.Text, 0))
+		}
+	case "007":
+		return Cf007Def(format, pluckByte(cf.Text, 0))
+	case "008":
+		return Cf008Def(format, material)
+	}
+
+	return fd, false
+}
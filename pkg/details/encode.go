@@ -0,0 +1,302 @@
+// Copyright 2017-2018 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package details
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+Encoding builds the text of the leader or of a 006, 007 or 008 from
+element values. This is the reverse of decoding.
+
+Values are keyed by element ID ("target_audience"), element key
+("008.bk.target_audience") or element name ("Target audience") and may
+be given as either the code ("j") or the label ("Juvenile"). Blanks
+in codes may be given as "#". Elements that contain multiple codes
+(Illustrations, Nature of contents, etc.) may be given as the codes
+("ab") or as a comma separated list of codes and/or labels ("Maps,
+Portraits"); unused code positions are left blank. Places of
+publication and languages must be well formed codes (two or three, and
+three, lower case letters) and may also be given by a name from the
+country and language code lists ("United States", "English", see
+countries.go and languages.go).
+
+Elements that are not given a value are set to their default: blanks
+if blank is a defined code for the element (or the element holds
+data), otherwise fill characters. For 006 and 007 fields the first
+position is set to the code of the form or category of material that
+the definition is for. The leader structure positions (record length,
+indicator count, base address, entry map, etc.) default to the values
+for an empty record; leader elements that have no blank code (record
+status, type of record, etc.) must be given.
+*/
+
+// leaderStructure contains the default leader values for an empty
+// record
+const leaderStructure = "00000     2200000   4500"
+
+// Encode builds the text of the leader or control field from the
+// element values. Unknown elements and undefined codes are rejected.
+func (fd FieldDef) Encode(values map[string]string) (string, error) {
+
+	b := []byte(strings.Repeat(" ", fd.Length()))
+	if fd.Tag == "LDR" {
+		copy(b, leaderStructure)
+	}
+
+	used := make(map[string]bool)
+
+	for _, e := range fd.Elements {
+
+		v, k, ok := fd.lookupValue(e, values)
+		if ok {
+			used[k] = true
+		}
+
+		var code string
+		var err error
+		if ok {
+			code, err = e.encode(v)
+			if err != nil {
+				return "", fmt.Errorf("%s %s: %s", fd.Tag, e.Name, err)
+			}
+		} else {
+			code, ok = fd.defaultCode(e)
+			if !ok {
+				return "", fmt.Errorf("%s %s: a value is required", fd.Tag, e.Name)
+			}
+		}
+
+		copy(b[e.Offset:e.Offset+e.Width], code)
+	}
+
+	for k := range values {
+		if !used[k] {
+			return "", fmt.Errorf("%s: unknown element %q", fd.Tag, k)
+		}
+	}
+
+	return string(b), nil
+}
+
+// lookupValue returns the value given for an element and the key that
+// it was given under
+func (fd FieldDef) lookupValue(e ElementDef, values map[string]string) (v, k string, ok bool) {
+	for _, k := range []string{e.ID(), fd.Key(e), e.Name} {
+		if v, ok := values[k]; ok {
+			return v, k, true
+		}
+	}
+	for k, v := range values {
+		if strings.EqualFold(k, e.Name) {
+			return v, k, true
+		}
+	}
+	return "", "", false
+}
+
+// defaultCode returns the default value for an element
+func (fd FieldDef) defaultCode(e ElementDef) (string, bool) {
+
+	if fd.Tag == "LDR" {
+		if e.Codes == nil {
+			return pluckBytes(leaderStructure, e.Offset, e.Width), true
+		}
+		if _, ok := e.Codes[strings.Repeat(" ", e.CodeWidth)]; ok {
+			return strings.Repeat(" ", e.Width), true
+		}
+		return "", false
+	}
+
+	if e.Offset == 0 && (fd.Tag == "006" || fd.Tag == "007") {
+		if _, ok := e.Codes[fd.code]; ok {
+			return fd.code, true
+		}
+		return "", false
+	}
+
+	return e.emptyCode(), true
+}
+
+// emptyCode returns blanks if blank is a defined code for the element
+// (or the element holds data), otherwise fill characters
+func (e ElementDef) emptyCode() string {
+
+	blank := strings.Repeat(" ", e.CodeWidth)
+	if _, ok := e.Codes[blank]; ok || e.Codes == nil {
+		return strings.Repeat(" ", e.Width)
+	}
+
+	return strings.Repeat("|", e.Width)
+}
+
+// encode converts the value given for an element to its code
+func (e ElementDef) encode(v string) (string, error) {
+
+	code := strings.Replace(v, "#", " ", -1)
+
+	switch e.FnType {
+	case "multi":
+		return e.encodeMulti(v)
+
+	case "lookup":
+		if c, ok := e.resolve(v); ok {
+			return c, nil
+		}
+		// The holdings Language code table only lists the special
+		// values
+		if names, minWidth := e.codeNames(); names != nil {
+			if c, ok := resolveName(names, v, minWidth, e.Width); ok {
+				return c, nil
+			}
+		}
+		return "", fmt.Errorf("undefined code %q", v)
+
+	case "hybrid":
+		if c, ok := e.resolve(v); ok && len(c) == e.Width {
+			return c, nil
+		}
+		if len(code) == e.Width && strings.Trim(code, "0123456789") == "" {
+			return code, nil
+		}
+		return "", fmt.Errorf("undefined code %q", v)
+	}
+
+	// Data (read and hybrid-date elements). Places and languages are
+	// codes that have no generated lookup list
+	if names, minWidth := e.codeNames(); names != nil {
+		if c, ok := resolveName(names, v, minWidth, e.Width); ok {
+			return c, nil
+		}
+		if len(code) != e.Width || strings.Trim(code, " |") != "" {
+			return "", fmt.Errorf("undefined code %q", v)
+		}
+	}
+
+	if len(code) != e.Width {
+		return "", fmt.Errorf("%q is not %d characters long", v, e.Width)
+	}
+
+	return code, nil
+}
+
+// encodeMulti converts the value given for a multi-code element to
+// its codes
+func (e ElementDef) encodeMulti(v string) (string, error) {
+
+	slots := e.Width / e.CodeWidth
+	blank := e.emptyCode()[:e.CodeWidth]
+
+	// The codes run together ("ab", "a###")
+	code := strings.Replace(v, "#", " ", -1)
+	if len(code) <= e.Width && len(code)%e.CodeWidth == 0 && !strings.Contains(code, ",") {
+		var l []string
+		for i := 0; i < len(code); i += e.CodeWidth {
+			c := code[i : i+e.CodeWidth]
+			if _, ok := e.Codes[c]; !ok {
+				l = nil
+				break
+			}
+			l = append(l, c)
+		}
+		if l != nil {
+			return strings.Join(l, "") + strings.Repeat(blank, slots-len(l)), nil
+		}
+	}
+
+	// A list of codes and/or labels
+	var l []string
+	for _, p := range strings.Split(v, ",") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		c, ok := e.resolve(p)
+		if !ok {
+			return "", fmt.Errorf("undefined code %q", p)
+		}
+		l = append(l, c)
+	}
+	if len(l) > slots {
+		return "", fmt.Errorf("too many codes (%d), at most %d are allowed", len(l), slots)
+	}
+
+	return strings.Join(l, "") + strings.Repeat(blank, slots-len(l)), nil
+}
+
+// codeNames returns the code list (see countries.go and languages.go)
+// for the place and language elements and the minimum width of their
+// codes
+func (e ElementDef) codeNames() (map[string]string, int) {
+	switch {
+	case e.Name == "Language" && e.Width == 3:
+		return languageNames, 3
+	case strings.HasPrefix(e.Name, "Place of publication"):
+		return countryNames, 2
+	}
+	return nil, 0
+}
+
+// resolveName returns the code, blank filled to the width of the
+// element, for a code or a name from a code list. As the code lists are
+// not complete any code of lower case letters of the right width is
+// accepted; the lists are only needed to resolve names.
+func resolveName(names map[string]string, v string, minWidth, width int) (string, bool) {
+
+	code := strings.TrimRight(strings.Replace(v, "#", " ", -1), " ")
+	if !isLowerCode(code, minWidth, width) {
+		var found bool
+		for c, name := range names {
+			if strings.EqualFold(v, name) && (!found || c < code) {
+				code, found = c, true
+			}
+		}
+		if !found {
+			return "", false
+		}
+	}
+
+	if len(code) > width {
+		return "", false
+	}
+
+	return code + strings.Repeat(" ", width-len(code)), true
+}
+
+// resolve returns the code for a code or label
+func (e ElementDef) resolve(v string) (string, bool) {
+
+	code := strings.Replace(v, "#", " ", -1)
+	if _, ok := e.Codes[code]; ok {
+		return code, true
+	}
+
+	// Several codes may have the same label, use the first
+	var match string
+	var found bool
+	for c, label := range e.Codes {
+		if strings.EqualFold(v, label) && (!found || c < match) {
+			match, found = c, true
+		}
+	}
+
+	return match, found
+}
+
+// isLowerCode determines if a code consists of between minWidth and
+// width lower case letters
+func isLowerCode(s string, minWidth, width int) bool {
+	if len(s) < minWidth || len(s) > width {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < 'a' || s[i] > 'z' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,89 @@
+// Copyright 2017-2018 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package details
+
+import (
+	"testing"
+
+	"github.com/gsiems/go-marc21/pkg/marc21"
+)
+
+func TestEncode(t *testing.T) {
+
+	fd, ok := GetFieldDef(marc21.Bibliography, "008", "BK")
+	if !ok {
+		t.Fatal("no books 008 definition")
+	}
+
+	tests := []struct {
+		name    string
+		values  map[string]string
+		want    string
+		wantErr bool
+	}{
+		{
+			"defaults",
+			map[string]string{},
+			"      |                      ||| |      ",
+			false,
+		},
+		{
+			"codes",
+			map[string]string{"place_of_publication_production_or_execution": "xxu", "language": "eng"},
+			"      |        xxu           ||| | eng  ",
+			false,
+		},
+		{
+			"names",
+			map[string]string{"place_of_publication_production_or_execution": "United States", "Language": "english"},
+			"      |        xxu           ||| | eng  ",
+			false,
+		},
+		{
+			"two character place",
+			map[string]string{"place_of_publication_production_or_execution": "fr", "language": "fre"},
+			"      |        fr            ||| | fre  ",
+			false,
+		},
+		{
+			"fill",
+			map[string]string{"place_of_publication_production_or_execution": "|||", "language": "|||"},
+			"      |        |||           ||| | |||  ",
+			false,
+		},
+		{
+			"labels and multiple codes",
+			map[string]string{"target_audience": "Juvenile", "illustrations": "Maps, Portraits", "008.bk.form_of_item": "d"},
+			"      |           bc  jd     ||| |      ",
+			false,
+		},
+		{
+			"unlisted codes",
+			map[string]string{"place_of_publication_production_or_execution": "xna", "language": "chr"},
+			"      |        xna           ||| | chr  ",
+			false,
+		},
+		{"malformed place", map[string]string{"place_of_publication_production_or_execution": "z1"}, "", true},
+		{"short place", map[string]string{"place_of_publication_production_or_execution": "z"}, "", true},
+		{"malformed language", map[string]string{"language": "ENG"}, "", true},
+		{"short language", map[string]string{"language": "en"}, "", true},
+		{"unknown language name", map[string]string{"language": "Klingon"}, "", true},
+		{"undefined code", map[string]string{"target_audience": "x"}, "", true},
+		{"too many codes", map[string]string{"illustrations": "a, b, c, d, e"}, "", true},
+		{"wrong length data", map[string]string{"date_entered_on_file": "9801"}, "", true},
+		{"unknown element", map[string]string{"no_such_element": "a"}, "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := fd.Encode(tt.values)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: Encode error = %v, want error %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: Encode = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright 2017-2018 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package details
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gsiems/go-marc21/pkg/marc21"
+)
+
+/*
+Explaining answers "what is at this position?" questions such as
+"008/29 on a map record", "007/04 for a sound recording" or "LDR/18 in
+an authority record": the element at the position, its full position
+range and the codes defined for it and, for a record, the value that
+the record has there.
+
+Positions are given as for SetElement ("008/29", "ldr/18", "007_2/04")
+and, for records, elements may also be given by key
+("008.form_of_item").
+*/
+
+// Explanation describes the element at a position of the leader or of
+// a control field
+type Explanation struct {
+	Format   string      `json:"format"`
+	Tag      string      `json:"tag"`
+	Material string      `json:"material,omitempty"`
+	Field    string      `json:"field"`
+	Position int         `json:"position"`
+	Element  ElementSpec `json:"element"`
+	// The field text and the decoded value(s) of the element, for
+	// records only
+	Text   string        `json:"text,omitempty"`
+	Values []ElementDesc `json:"values,omitempty"`
+}
+
+// ExplainPosition explains a position of the leader or of a control
+// field for the specified record format and code (see MaterialDef).
+// For the bibliography 008, and for the 006/00 and 007/00, the code may
+// be omitted for the positions that are the same for all materials.
+func ExplainPosition(format int, tag, code string, pos int) (x Explanation, err error) {
+
+	tag = strings.ToUpper(tag)
+
+	fd, ok := GetFieldDef(format, tag, code)
+	if !ok && code == "" {
+		fd, ok = commonFieldDef(format, tag, pos)
+	}
+	if !ok {
+		if code == "" {
+			return x, fmt.Errorf("%s %s/%02d depends on the material, specify one of %s", formatNames[format], tag, pos, materialCodes(format, tag))
+		}
+		return x, fmt.Errorf("%s %s has no definition for %q, specify one of %s", formatNames[format], tag, code, materialCodes(format, tag))
+	}
+
+	return fd.explain(elementRef{tag: tag, pos: pos}, "", false)
+}
+
+// ExplainRecord explains an element of the leader or of a control field
+// of a record, given by position or key. Where several fields contain
+// the element (006, 007) each is explained.
+func ExplainRecord(rec marc21.Record, key string) (l []Explanation, err error) {
+
+	ref, err := parseElementRef(key)
+	if err != nil {
+		return l, err
+	}
+
+	format := rec.RecordFormat()
+
+	var material string
+	if format == marc21.Bibliography {
+		material, _ = rec.BibliographyMaterialType()
+	}
+
+	if ref.tag == "LDR" {
+		fd, ok := LeaderDef(format)
+		if !ok {
+			return l, fmt.Errorf("%s: unknown record format", key)
+		}
+		x, err := fd.explain(ref, rec.Leader.Text, true)
+		if err != nil {
+			return l, fmt.Errorf("%s: %s", key, err)
+		}
+		return append(l, x), nil
+	}
+
+	var n int
+	for _, cf := range rec.Controlfields {
+		if cf.Tag != ref.tag {
+			continue
+		}
+		n++
+		if ref.occ > 0 && n != ref.occ {
+			continue
+		}
+		fd, ok := controlfieldDef(format, material, cf)
+		if !ok || !fd.hasElement(ref) {
+			continue
+		}
+		x, err := fd.explain(ref, cf.Text, true)
+		if err != nil {
+			return l, fmt.Errorf("%s: %s", key, err)
+		}
+		l = append(l, x)
+	}
+
+	switch {
+	case len(l) > 0:
+	case n == 0:
+		return l, fmt.Errorf("%s: the record has no %s field", key, ref.tag)
+	case ref.pos >= 0:
+		return l, fmt.Errorf("%s: no element is defined at position %02d", key, ref.pos)
+	default:
+		return l, fmt.Errorf("%s: no %s field has the element", key, ref.tag)
+	}
+
+	return l, nil
+}
+
+// explain explains the referenced element and, if there is a record,
+// decodes its value
+func (fd FieldDef) explain(ref elementRef, text string, decode bool) (x Explanation, err error) {
+
+	e, ok := fd.findElement(ref)
+	if !ok {
+		if ref.pos >= 0 {
+			return x, fmt.Errorf("%s %s has no position %02d (length %d)", fd.Name, fd.Tag, ref.pos, fd.Length())
+		}
+		return x, fmt.Errorf("the %s definition has no such element", fd.Name)
+	}
+
+	x = Explanation{
+		Format:   fd.Format,
+		Tag:      fd.Tag,
+		Material: fd.Material,
+		Field:    fd.Name,
+		Position: ref.pos,
+		Element:  fd.elementSpec(e),
+	}
+	if ref.pos < 0 {
+		x.Position = e.Offset
+	}
+
+	if !decode {
+		return x, nil
+	}
+
+	x.Text = text
+	for _, ed := range fd.Decode(text) {
+		if ed.ID != x.Element.ID {
+			continue
+		}
+		// Only the code at the position for multi-code elements
+		if ref.pos >= 0 && (ref.pos < ed.Offset || ref.pos >= ed.Offset+ed.Width) {
+			continue
+		}
+		x.Values = append(x.Values, ed)
+	}
+
+	return x, nil
+}
+
+// commonFieldDef returns a definition for the positions of the
+// bibliography 008 (00-17, 35-39) and of the 006 and 007 (00) that are
+// the same for all materials
+func commonFieldDef(format int, tag string, pos int) (fd FieldDef, ok bool) {
+
+	switch {
+	case tag == "008" && format == marc21.Bibliography && (pos < 18 || pos > 34):
+		fd, ok = Cf008Def(format, "BK")
+	case tag == "006" && format == marc21.Bibliography && pos == 0:
+		fd, ok = Cf006Def("a")
+	case tag == "007" && pos == 0:
+		l := Materials(format, tag)
+		if len(l) > 0 {
+			fd, ok = Cf007Def(format, l[0].Code)
+		}
+	}
+
+	fd.Material = ""
+	fd.Name = commonFieldNames[tag]
+	fd.code = ""
+
+	return fd, ok
+}
+
+var commonFieldNames = map[string]string{
+	"006": "Additional material characteristics",
+	"007": "Physical description fixed field",
+	"008": "Fixed-length data elements",
+}
+
+// materialCodes returns the list of codes for the definitions of a
+// field
+func materialCodes(format int, tag string) string {
+
+	var l []string
+	for _, m := range Materials(format, tag) {
+		l = append(l, fmt.Sprintf("%s (%s)", m.Code, m.Name))
+	}
+	if len(l) == 0 {
+		return "nothing (no definitions)"
+	}
+
+	return strings.Join(l, ", ")
+}
@@ -0,0 +1,481 @@
+// Copyright 2017-2018 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package details
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gsiems/go-marc21/pkg/marc21"
+)
+
+/*
+Filters select records using conditions on the decoded leader and
+control field elements. For example:
+
+	008.bk.target_audience = "Juvenile"
+	ldr.encoding_level in (3, 5, 7)
+	007.category = "Videorecording" and 007.vir.videorecording_format = "DVD"
+	not (008.language = eng or 008.language = "")
+
+Conditions consist of an element key, an operator and one or more
+values. The operators are "=", "!=", "in (...)" and "not in (...)".
+Conditions may be combined using "and", "or", "not" and parentheses.
+
+Element keys are as returned by FieldDef.Key. The material may be
+omitted ("008.target_audience" matches the target audience of any
+material type) and the element ID may be shortened to its leading
+words ("007.category" matches "007.category_of_material"). Keys and
+materials that do not match any defined element are rejected.
+
+Values match either the code (blanks may be given as "#") or,
+ignoring case, the label. Values may be quoted using double quotes
+and must be quoted if they contain anything other than letters,
+digits, "#", "|", "-" and "_".
+
+A condition is true if any element having the key (there may be
+several 007 fields or several codes for an element) matches the
+value(s). Negated conditions ("!=" and "not in") are true if no
+element matches.
+*/
+
+// Filter is a parsed filter expression
+type Filter struct {
+	expr string
+	root filterNode
+}
+
+// filterNode is a node in the parsed filter expression
+type filterNode interface {
+	match(l []ElementDesc) bool
+}
+
+type filterAnd struct {
+	left, right filterNode
+}
+
+type filterOr struct {
+	left, right filterNode
+}
+
+type filterNot struct {
+	node filterNode
+}
+
+// filterCondition is a single element condition
+type filterCondition struct {
+	tag      string
+	material string
+	id       string
+	values   []string
+}
+
+// filterParser holds the state of a filter expression being parsed
+type filterParser struct {
+	tokens []string
+	pos    int
+}
+
+// ParseFilter parses a filter expression
+func ParseFilter(expr string) (*Filter, error) {
+
+	tokens, err := filterTokens(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty filter")
+	}
+
+	p := filterParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q in filter", p.tokens[p.pos])
+	}
+
+	return &Filter{expr: expr, root: root}, nil
+}
+
+// String returns the filter expression
+func (f Filter) String() string {
+	return f.expr
+}
+
+// Match determines if a record matches the filter
+func (f Filter) Match(rec marc21.Record) bool {
+
+	var l []ElementDesc
+	for _, fd := range DescribeRecord(rec).Fields {
+		l = append(l, fd.Elements...)
+	}
+
+	return f.root.match(l)
+}
+
+func (n filterAnd) match(l []ElementDesc) bool {
+	return n.left.match(l) && n.right.match(l)
+}
+
+func (n filterOr) match(l []ElementDesc) bool {
+	return n.left.match(l) || n.right.match(l)
+}
+
+func (n filterNot) match(l []ElementDesc) bool {
+	return !n.node.match(l)
+}
+
+func (n filterCondition) match(l []ElementDesc) bool {
+	for _, e := range l {
+		if !n.selects(e.Key) {
+			continue
+		}
+		for _, v := range n.values {
+			if matchesValue(e, v) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// selects determines if the condition applies to the element having
+// the specified key
+func (n filterCondition) selects(key string) bool {
+
+	p := strings.Split(key, ".")
+
+	var material string
+	if len(p) == 3 {
+		material = p[1]
+	}
+	id := p[len(p)-1]
+
+	if p[0] != n.tag || (n.material != "" && n.material != material) {
+		return false
+	}
+
+	return id == n.id || strings.HasPrefix(id, n.id+"_")
+}
+
+// matchesValue determines if a value matches the code or label of an
+// element
+func matchesValue(e ElementDesc, v string) bool {
+	if v == e.Code || v == strings.Replace(e.Code, " ", "#", -1) {
+		return true
+	}
+	return e.Label != "" && strings.EqualFold(v, e.Label)
+}
+
+// parseOr parses: and-expression { "or" and-expression }
+func (p *filterParser) parseOr() (filterNode, error) {
+
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.acceptKeyword("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = filterOr{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+// parseAnd parses: unary { "and" unary }
+func (p *filterParser) parseAnd() (filterNode, error) {
+
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.acceptKeyword("and") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = filterAnd{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+// parseUnary parses: "not" unary | "(" or-expression ")" | condition
+func (p *filterParser) parseUnary() (filterNode, error) {
+
+	if p.acceptKeyword("not") {
+		n, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return filterNot{node: n}, nil
+	}
+
+	if p.accept("(") {
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(")") {
+			return nil, p.expected(")")
+		}
+		return n, nil
+	}
+
+	return p.parseCondition()
+}
+
+// parseCondition parses: key ( "=" | "!=" ) value | key [ "not" ] "in" "(" value { "," value } ")"
+func (p *filterParser) parseCondition() (filterNode, error) {
+
+	key, ok := p.next()
+	if !ok || isFilterPunct(key) || isQuoted(key) {
+		return nil, p.expected("element key")
+	}
+
+	n, err := newFilterCondition(key)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case p.accept("="):
+		v, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		n.values = []string{v}
+		return n, nil
+
+	case p.accept("!="):
+		v, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		n.values = []string{v}
+		return filterNot{node: n}, nil
+
+	case p.acceptKeyword("in"):
+		n.values, err = p.parseValueList()
+		return n, err
+
+	case p.acceptKeyword("not"):
+		if !p.acceptKeyword("in") {
+			return nil, p.expected("in")
+		}
+		n.values, err = p.parseValueList()
+		return filterNot{node: n}, err
+	}
+
+	return nil, p.expected("=, !=, in or not in")
+}
+
+// parseValueList parses: "(" value { "," value } ")"
+func (p *filterParser) parseValueList() (l []string, err error) {
+
+	if !p.accept("(") {
+		return nil, p.expected("(")
+	}
+
+	for {
+		v, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		l = append(l, v)
+
+		if p.accept(")") {
+			return l, nil
+		}
+		if !p.accept(",") {
+			return nil, p.expected(", or )")
+		}
+	}
+}
+
+// parseValue parses a quoted or unquoted value
+func (p *filterParser) parseValue() (string, error) {
+
+	v, ok := p.next()
+	if !ok || isFilterPunct(v) {
+		return "", p.expected("value")
+	}
+	if isQuoted(v) {
+		return v[1 : len(v)-1], nil
+	}
+
+	return v, nil
+}
+
+// newFilterCondition creates a condition for an element key
+func newFilterCondition(key string) (n filterCondition, err error) {
+
+	p := strings.Split(strings.ToLower(key), ".")
+
+	switch len(p) {
+	case 2:
+		n.tag, n.id = p[0], p[1]
+	case 3:
+		n.tag, n.material, n.id = p[0], p[1], p[2]
+	default:
+		return n, fmt.Errorf("invalid element key %q", key)
+	}
+
+	switch n.tag {
+	case "ldr", "006", "007", "008":
+	default:
+		return n, fmt.Errorf("invalid element key %q: tag must be one of ldr, 006, 007 or 008", key)
+	}
+
+	if n.id == "" {
+		return n, fmt.Errorf("invalid element key %q", key)
+	}
+
+	// A mistyped key or material would silently match nothing (or,
+	// negated, everything) so the key must match a defined element
+	keys := elementKeys()
+	if n.material != "" {
+		var materials []string
+		seen := make(map[string]bool)
+		for _, k := range keys {
+			p := strings.Split(k, ".")
+			if len(p) == 3 && p[0] == n.tag && !seen[p[1]] {
+				seen[p[1]] = true
+				materials = append(materials, p[1])
+			}
+		}
+		if !seen[n.material] {
+			return n, fmt.Errorf("invalid element key %q: unknown %s material %q, expected one of %s", key, n.tag, n.material, strings.Join(materials, ", "))
+		}
+	}
+	for _, k := range keys {
+		if n.selects(k) {
+			return n, nil
+		}
+	}
+
+	return n, fmt.Errorf("invalid element key %q: no such element", key)
+}
+
+// elementKeys returns the keys of the elements of all of the field
+// definitions
+func elementKeys() (l []string) {
+
+	seen := make(map[string]bool)
+	for _, format := range Formats() {
+		for _, tag := range Tags(format) {
+			for _, m := range Materials(format, tag) {
+				fd, ok := GetFieldDef(format, tag, m.Code)
+				if !ok {
+					continue
+				}
+				for _, e := range fd.Elements {
+					k := fd.Key(e)
+					if !seen[k] {
+						seen[k] = true
+						l = append(l, k)
+					}
+				}
+			}
+		}
+	}
+
+	return l
+}
+
+func (p *filterParser) next() (string, bool) {
+	if p.pos >= len(p.tokens) {
+		return "", false
+	}
+	t := p.tokens[p.pos]
+	p.pos++
+	return t, true
+}
+
+func (p *filterParser) accept(t string) bool {
+	if p.pos < len(p.tokens) && p.tokens[p.pos] == t {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *filterParser) acceptKeyword(k string) bool {
+	if p.pos < len(p.tokens) && strings.EqualFold(p.tokens[p.pos], k) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *filterParser) expected(what string) error {
+	if p.pos >= len(p.tokens) {
+		return fmt.Errorf("expected %s at end of filter", what)
+	}
+	return fmt.Errorf("expected %s at %q in filter", what, p.tokens[p.pos])
+}
+
+func isFilterPunct(t string) bool {
+	switch t {
+	case "(", ")", ",", "=", "!=":
+		return true
+	}
+	return false
+}
+
+func isQuoted(t string) bool {
+	return len(t) > 1 && t[0] == '"'
+}
+
+// filterTokens splits a filter expression into tokens. Quoted
+// tokens retain their quotes so that they can be distinguished from
+// punctuation and keywords
+func filterTokens(expr string) (l []string, err error) {
+
+	for i := 0; i < len(expr); {
+		c := expr[i]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+
+		case c == '(' || c == ')' || c == ',' || c == '=':
+			l = append(l, expr[i:i+1])
+			i++
+
+		case c == '!':
+			if i+1 >= len(expr) || expr[i+1] != '=' {
+				return nil, fmt.Errorf("unexpected %q in filter", "!")
+			}
+			l = append(l, "!=")
+			i += 2
+
+		case c == '"':
+			j := strings.IndexByte(expr[i+1:], '"')
+			if j < 0 {
+				return nil, fmt.Errorf("unterminated quoted value in filter")
+			}
+			l = append(l, expr[i:i+j+2])
+			i += j + 2
+
+		default:
+			j := i
+			for j < len(expr) && strings.IndexByte(" \t\n\r(),=!\"", expr[j]) < 0 {
+				j++
+			}
+			l = append(l, expr[i:j])
+			i = j
+		}
+	}
+
+	return l, nil
+}
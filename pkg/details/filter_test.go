@@ -0,0 +1,80 @@
+// Copyright 2017-2018 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package details
+
+import (
+	"testing"
+)
+
+func TestParseFilter(t *testing.T) {
+
+	tests := []struct {
+		expr string
+		ok   bool
+	}{
+		{`008.bk.target_audience = "Juvenile"`, true},
+		{`ldr.encoding_level in (3, 5, 7)`, true},
+		{`007.category = "Videorecording" and 007.vir.videorecording_format = "DVD"`, true},
+		{`not (008.language = eng or 008.language = "")`, true},
+		{`008.target_audience not in (j, "Adult")`, true},
+		{`008.kind_of_record = a`, true},
+		{``, false},
+		{`008.bk.target_audience`, false},
+		{`008.bk.target_audience = `, false},
+		{`008.bk.target_audience in (j`, false},
+		{`(ldr.encoding_level = 7`, false},
+		{`ldr.encoding_level = 7 7`, false},
+		{`245.title = x`, false},
+		{`008 = x`, false},
+		{`008.bx.target_audience = j`, false},
+		{`008.bk.target_audiense != j`, false},
+		{`ldr.encodng_level = 7`, false},
+		{`008.language = "eng`, false},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseFilter(tt.expr)
+		if (err == nil) != tt.ok {
+			t.Errorf("ParseFilter(%q) error = %v, want ok = %v", tt.expr, err, tt.ok)
+		}
+	}
+}
+
+func TestFilterMatch(t *testing.T) {
+
+	book := testRecord(testBookLeader, "001 rec1", "007 cr||||||||||||", "008 "+testBook008)
+	minimal := testRecord("00428cam a22001817i 4500", "001 rec2", "008 "+testBook008)
+
+	tests := []struct {
+		expr string
+		book bool
+		min  bool
+	}{
+		{`008.bk.target_audience = "Juvenile"`, true, true},
+		{`008.target_audience = j`, true, true},
+		{`008.bk.target_audience != j`, false, false},
+		{`ldr.encoding_level in (3, 5, 7)`, false, true},
+		{`ldr.encoding_level = #`, true, false},
+		{`ldr.encoding_level = "full level"`, true, false},
+		{`007.category = "Computer file"`, true, false},
+		{`not 007.category = c`, false, true},
+		{`008.language = eng and ldr.encoding_level not in (7)`, true, false},
+		{`008.bk.form_of_item = d or 007.category = c`, true, true},
+	}
+
+	for _, tt := range tests {
+		f, err := ParseFilter(tt.expr)
+		if err != nil {
+			t.Errorf("ParseFilter(%q) error: %s", tt.expr, err)
+			continue
+		}
+		if got := f.Match(book); got != tt.book {
+			t.Errorf("%q: Match(book) = %v, want %v", tt.expr, got, tt.book)
+		}
+		if got := f.Match(minimal); got != tt.min {
+			t.Errorf("%q: Match(minimal) = %v, want %v", tt.expr, got, tt.min)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright 2017-2018 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package details
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/gsiems/go-marc21/pkg/marc21"
+)
+
+// HTMLReportHeader being the header (doctype, styles, scripts and
+// opening body tag) of a self-contained HTML record report
+const HTMLReportHeader = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="UTF-8">
+<title>MARC record report</title>
+<style>
+body { font-family: sans-serif; font-size: 14px; margin: 1em 2em; }
+div.record { border-top: 3px solid #444; margin-top: 2em; }
+p.summary { font-style: italic; }
+table { border-collapse: collapse; margin-bottom: 1em; }
+th, td { border: 1px solid #bbb; padding: 2px 6px; text-align: left; vertical-align: top; }
+table.grid th { font-size: 10px; font-weight: normal; color: #666; text-align: center; padding: 1px 3px; }
+table.grid td { font-family: monospace; font-size: 16px; text-align: center; padding: 2px 4px; cursor: default; }
+.e0 { background: #e8f0fe; }
+.e1 { background: #fef7e0; }
+.blank { color: #999; }
+.fill { color: #777; font-style: italic; }
+.undefined, .obsolete, .missing { background: #f8c8c8 !important; color: #900; font-weight: bold; }
+.hl { outline: 2px solid #1a73e8; background: #c6dafc !important; }
+td.mono { font-family: monospace; white-space: pre; }
+span.sfc { color: #1a73e8; font-weight: bold; }
+</style>
+<script>
+function hl(ev, on) {
+	var t = ev.target.closest ? ev.target.closest("[data-el]") : null;
+	if (!t) { return; }
+	var f = t.closest("div.field");
+	var l = f.querySelectorAll("[data-el='" + t.getAttribute("data-el") + "']");
+	for (var i = 0; i < l.length; i++) {
+		l[i].classList.toggle("hl", on);
+	}
+}
+document.addEventListener("mouseover", function(ev) { hl(ev, true); });
+document.addEventListener("mouseout", function(ev) { hl(ev, false); });
+</script>
+</head>
+<body>
+`
+
+// HTMLReportFooter being the footer (closing body and html tags) of an
+// HTML record report
+const HTMLReportFooter = `</body>
+</html>
+`
+
+// RecordAsHTML converts a record to an HTML report fragment. The
+// leader, 006, 007 and 008 are shown as character grids where hovering
+// over a character highlights all the characters of the element and
+// shows the element label. Undefined and obsolete codes and missing
+// elements are flagged. The fragment is intended to be wrapped by
+// HTMLReportHeader and HTMLReportFooter.
+func RecordAsHTML(rec marc21.Record) (ret string, err error) {
+
+	rd := DescribeRecord(rec)
+
+	ret = "<div class=\"record\">\n"
+	ret += fmt.Sprintf("<h2>%s %s</h2>\n", html.EscapeString(rd.ControlNumber), html.EscapeString(recordTitle(rec)))
+	ret += fmt.Sprintf("<p class=\"summary\">%s</p>\n", html.EscapeString(Summarize(rec, SummaryVerbose)))
+
+	var others []FieldDesc
+	for _, fd := range rd.Fields {
+		if len(fd.Elements) == 0 {
+			others = append(others, fd)
+			continue
+		}
+		ret += fieldAsHTML(fd)
+	}
+
+	ret += "<h3>Control fields</h3>\n<table>\n"
+	for _, fd := range others {
+		ret += fmt.Sprintf("<tr><th>%s</th><td class=\"mono\">%s</td></tr>\n", html.EscapeString(fd.Tag), html.EscapeString(fd.Text))
+	}
+	ret += "</table>\n"
+
+	ret += "<h3>Data fields</h3>\n<table>\n"
+	ret += "<tr><th>Tag</th><th>Name</th><th>Ind</th><th>Subfields</th></tr>\n"
+	for _, df := range rec.Datafields {
+		var name string
+		if rec.RecordFormat() == marc21.Bibliography {
+			name = bibliographyDatafieldNames[df.GetTag()]
+		}
+		ret += fmt.Sprintf("<tr><th>%s</th><td>%s</td><td class=\"mono\">%s%s</td><td>",
+			html.EscapeString(df.GetTag()), html.EscapeString(name), html.EscapeString(df.GetInd1()), html.EscapeString(df.GetInd2()))
+		for _, sf := range df.Subfields {
+			ret += fmt.Sprintf("<span class=\"sfc\">$%s</span> %s ", html.EscapeString(sf.GetCode()), html.EscapeString(sf.GetText()))
+		}
+		ret += "</td></tr>\n"
+	}
+	ret += "</table>\n"
+
+	ret += "</div>\n"
+
+	return ret, nil
+}
+
+// fieldAsHTML returns the character grid and element list for a
+// decoded leader or control field
+func fieldAsHTML(fd FieldDesc) (ret string) {
+
+	title := fd.Tag
+	if fd.Material != "" {
+		title += " (" + fd.Material + ")"
+	}
+
+	// Determine which element, if any, each character belongs to
+	owner := make([]int, len(fd.Text))
+	for i := range owner {
+		owner[i] = -1
+	}
+	for j, e := range fd.Elements {
+		for i := e.Offset; i < e.Offset+e.Width && i < len(owner); i++ {
+			owner[i] = j
+		}
+	}
+
+	ret = "<div class=\"field\">\n"
+	ret += fmt.Sprintf("<h3>%s</h3>\n", html.EscapeString(title))
+	ret += "<table class=\"grid\">\n<tr>"
+	for i := range fd.Text {
+		ret += fmt.Sprintf("<th>%02d</th>", i)
+	}
+	ret += "</tr>\n<tr>"
+	for i := 0; i < len(fd.Text); i++ {
+		c := fd.Text[i : i+1]
+
+		var class []string
+		if c == " " {
+			c = "#"
+			class = append(class, "blank")
+		}
+
+		j := owner[i]
+		if j < 0 {
+			ret += fmt.Sprintf("<td class=%q>%s</td>", strings.Join(class, " "), html.EscapeString(c))
+			continue
+		}
+
+		e := fd.Elements[j]
+		class = append(class, fmt.Sprintf("e%d", elementGroup(fd.Elements, j)%2), e.Status)
+		ret += fmt.Sprintf("<td class=%q data-el=\"e%d\" title=\"%s\">%s</td>",
+			strings.Join(class, " "), j, html.EscapeString(elementTitle(e)), html.EscapeString(c))
+	}
+	ret += "</tr>\n</table>\n"
+
+	ret += "<table class=\"elements\">\n"
+	ret += "<tr><th>Pos</th><th>Element</th><th>Code</th><th>Label</th><th>Status</th></tr>\n"
+	for j, e := range fd.Elements {
+		ret += fmt.Sprintf("<tr data-el=\"e%d\" class=%q><td>%s</td><td>%s</td><td class=\"mono\">%s</td><td>%s</td><td>%s</td></tr>\n",
+			j, e.Status, e.Positions, html.EscapeString(e.Name), html.EscapeString(strings.Replace(e.Code, " ", "#", -1)),
+			html.EscapeString(e.Label), e.Status)
+	}
+	ret += "</table>\n"
+	ret += "</div>\n"
+
+	return ret
+}
+
+// elementGroup returns the ordinal of the element that the j-th
+// decoded value belongs to so that all the values of a multi-code
+// element share the same shading
+func elementGroup(l []ElementDesc, j int) (g int) {
+	for i := 1; i <= j; i++ {
+		if l[i].ID != l[i-1].ID {
+			g++
+		}
+	}
+	return g
+}
+
+// elementTitle returns the hover text for an element
+func elementTitle(e ElementDesc) string {
+
+	s := fmt.Sprintf("%s %s: %s", e.Positions, e.Name, strings.Replace(e.Code, " ", "#", -1))
+	if e.Label != "" {
+		s += " = " + e.Label
+	}
+	if e.Status == StatusUndefined || e.Status == StatusObsolete || e.Status == StatusMissing {
+		s += " [" + e.Status + "]"
+	}
+
+	return s
+}
+
+// recordTitle returns the title proper (245 $a $b) of a record
+func recordTitle(rec marc21.Record) string {
+
+	var t []string
+	for _, df := range rec.GetDatafields("245") {
+		for _, sf := range df.GetSubfields("ab") {
+			t = append(t, sf.GetText())
+		}
+	}
+
+	return strings.TrimRight(strings.Join(t, " "), " /:;,.")
+}
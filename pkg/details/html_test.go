@@ -0,0 +1,34 @@
+// Copyright 2017-2018 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package details
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRecordAsHTML(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		cfs     []string
+		summary string
+	}{
+		{"no 008", []string{"001 rec1"}, "A book; full-level record"},
+		{"short 008", []string{"001 rec1", "008 980101"}, "A book; full-level record"},
+		{"book", []string{"001 rec1", "008 " + testBook008}, "A large-print juvenile work of fiction"},
+	}
+
+	for _, tt := range tests {
+		s, err := RecordAsHTML(testRecord(testBookLeader, tt.cfs...))
+		if err != nil {
+			t.Errorf("%s: RecordAsHTML error: %s", tt.name, err)
+			continue
+		}
+		if !strings.Contains(s, tt.summary) {
+			t.Errorf("%s: RecordAsHTML does not contain %q", tt.name, tt.summary)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2017-2018 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package details
+
+/*
+http://www.loc.gov/marc/languages/language_code.html
+
+The 008/35-37 (Language) element is a "read" element so there is no
+generated lookup list for it. This is a subset of the MARC Code List
+for Languages that covers the languages most commonly encountered;
+codes that are not listed here are reported as-is.
+*/
+
+var languageNames = map[string]string{
+	"afr": "Afrikaans",
+	"alb": "Albanian",
+	"amh": "Amharic",
+	"ara": "Arabic",
+	"arm": "Armenian",
+	"baq": "Basque",
+	"bel": "Belarusian",
+	"ben": "Bengali",
+	"bos": "Bosnian",
+	"bul": "Bulgarian",
+	"bur": "Burmese",
+	"cat": "Catalan",
+	"chi": "Chinese",
+	"cze": "Czech",
+	"dan": "Danish",
+	"dut": "Dutch",
+	"eng": "English",
+	"epo": "Esperanto",
+	"est": "Estonian",
+	"fin": "Finnish",
+	"fre": "French",
+	"geo": "Georgian",
+	"ger": "German",
+	"gla": "Scottish Gaelic",
+	"gle": "Irish",
+	"grc": "Greek, Ancient (to 1453)",
+	"gre": "Greek, Modern (1453- )",
+	"guj": "Gujarati",
+	"hat": "Haitian French Creole",
+	"haw": "Hawaiian",
+	"heb": "Hebrew",
+	"hin": "Hindi",
+	"hmn": "Hmong",
+	"hrv": "Croatian",
+	"hun": "Hungarian",
+	"ice": "Icelandic",
+	"ind": "Indonesian",
+	"ita": "Italian",
+	"jpn": "Japanese",
+	"kaz": "Kazakh",
+	"khm": "Khmer",
+	"kor": "Korean",
+	"kur": "Kurdish",
+	"lao": "Lao",
+	"lat": "Latin",
+	"lav": "Latvian",
+	"lit": "Lithuanian",
+	"mac": "Macedonian",
+	"may": "Malay",
+	"mul": "Multiple languages",
+	"nav": "Navajo",
+	"nep": "Nepali",
+	"nor": "Norwegian",
+	"oji": "Ojibwa",
+	"pan": "Panjabi",
+	"per": "Persian",
+	"pol": "Polish",
+	"por": "Portuguese",
+	"rum": "Romanian",
+	"rus": "Russian",
+	"san": "Sanskrit",
+	"sgn": "Sign languages",
+	"slo": "Slovak",
+	"slv": "Slovenian",
+	"som": "Somali",
+	"spa": "Spanish",
+	"srp": "Serbian",
+	"swa": "Swahili",
+	"swe": "Swedish",
+	"tag": "Tagalog",
+	"tam": "Tamil",
+	"tha": "Thai",
+	"tib": "Tibetan",
+	"tur": "Turkish",
+	"ukr": "Ukrainian",
+	"und": "Undetermined",
+	"urd": "Urdu",
+	"vie": "Vietnamese",
+	"wel": "Welsh",
+	"yid": "Yiddish",
+	"zxx": "No linguistic content",
+}
@@ -63,6 +63,26 @@ func parseAuthorityLdr(s string) (ldr LdrDesc) {
 	return ldr
 }
 
+// authorityLdrElements defines the elements that are parsed by
+// parseAuthorityLdr
+var authorityLdrElements = []ElementDef{
+	{Name: "Record length", Offset: 0, Width: 5, CodeWidth: 5, FnType: "read"},
+	{Name: "Record status", Offset: 5, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: authorityLdrRecordStatus},
+	{Name: "Type of record", Offset: 6, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: authorityLdrTypeOfRecord},
+	{Name: "Undefined character positions", Offset: 7, Width: 2, CodeWidth: 2, FnType: "read"},
+	{Name: "Character coding scheme", Offset: 9, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: authorityLdrCharacterCodingScheme},
+	{Name: "Indicator count", Offset: 10, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Subfield code length", Offset: 11, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Base address of data", Offset: 12, Width: 5, CodeWidth: 5, FnType: "read"},
+	{Name: "Encoding level", Offset: 17, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: authorityLdrEncodingLevel},
+	{Name: "Punctuation policy", Offset: 18, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: authorityLdrPunctuationPolicy},
+	{Name: "Undefined", Offset: 19, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Length of the length-of-field portion", Offset: 20, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Length of the starting-character-position portion", Offset: 21, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Length of the implementation-defined portion", Offset: 22, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Undefined", Offset: 23, Width: 1, CodeWidth: 1, FnType: "read"},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Bibliography
 var bibliographyLdrRecordStatus = map[string]string{
@@ -168,6 +188,27 @@ func parseBibliographyLdr(s string) (ldr LdrDesc) {
 	return ldr
 }
 
+// bibliographyLdrElements defines the elements that are parsed by
+// parseBibliographyLdr
+var bibliographyLdrElements = []ElementDef{
+	{Name: "Logical record length", Offset: 0, Width: 5, CodeWidth: 5, FnType: "read"},
+	{Name: "Record status", Offset: 5, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliographyLdrRecordStatus},
+	{Name: "Type of record", Offset: 6, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliographyLdrTypeOfRecord},
+	{Name: "Bibliographic level", Offset: 7, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliographyLdrBibliographicLevel},
+	{Name: "Type of control", Offset: 8, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliographyLdrTypeOfControl},
+	{Name: "Character coding scheme", Offset: 9, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliographyLdrCharacterCodingScheme},
+	{Name: "Indicator count", Offset: 10, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Subfield code count", Offset: 11, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Base address of data", Offset: 12, Width: 5, CodeWidth: 5, FnType: "read"},
+	{Name: "Encoding level", Offset: 17, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliographyLdrEncodingLevel},
+	{Name: "Descriptive cataloging form", Offset: 18, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliographyLdrDescriptiveCatalogingForm},
+	{Name: "Multipart resource record level", Offset: 19, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: bibliographyLdrMultipartResourceRecordLevel},
+	{Name: "Length of the length-of-field portion", Offset: 20, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Length of the starting-character-position portion", Offset: 21, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Length of the implementation-defined portion", Offset: 22, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Undefined Entry map character position", Offset: 23, Width: 1, CodeWidth: 1, FnType: "read"},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Classification
 var classificationLdrRecordStatus = map[string]string{
@@ -218,6 +259,25 @@ func parseClassificationLdr(s string) (ldr LdrDesc) {
 	return ldr
 }
 
+// classificationLdrElements defines the elements that are parsed by
+// parseClassificationLdr
+var classificationLdrElements = []ElementDef{
+	{Name: "Record length", Offset: 0, Width: 5, CodeWidth: 5, FnType: "read"},
+	{Name: "Record status", Offset: 5, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: classificationLdrRecordStatus},
+	{Name: "Type of record", Offset: 6, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: classificationLdrTypeOfRecord},
+	{Name: "Undefined character positions", Offset: 7, Width: 2, CodeWidth: 2, FnType: "read"},
+	{Name: "Character coding scheme", Offset: 9, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: classificationLdrCharacterCodingScheme},
+	{Name: "Indicator count", Offset: 10, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Subfield code length", Offset: 11, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Base address of data", Offset: 12, Width: 5, CodeWidth: 5, FnType: "read"},
+	{Name: "Encoding level", Offset: 17, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: classificationLdrEncodingLevel},
+	{Name: "Undefined character positions", Offset: 18, Width: 2, CodeWidth: 2, FnType: "read"},
+	{Name: "Length of the length-of-field portion", Offset: 20, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Length of the starting-character-position portion", Offset: 21, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Length of the implementation-defined portion", Offset: 22, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Undefined", Offset: 23, Width: 1, CodeWidth: 1, FnType: "read"},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Community
 var communityLdrRecordStatus = map[string]string{
@@ -270,6 +330,25 @@ func parseCommunityLdr(s string) (ldr LdrDesc) {
 	return ldr
 }
 
+// communityLdrElements defines the elements that are parsed by
+// parseCommunityLdr
+var communityLdrElements = []ElementDef{
+	{Name: "Record length", Offset: 0, Width: 5, CodeWidth: 5, FnType: "read"},
+	{Name: "Record status", Offset: 5, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: communityLdrRecordStatus},
+	{Name: "Type of record", Offset: 6, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: communityLdrTypeOfRecord},
+	{Name: "Kind of data", Offset: 7, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: communityLdrKindOfData},
+	{Name: "Undefined character position", Offset: 8, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Character coding scheme", Offset: 9, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: communityLdrCharacterCodingScheme},
+	{Name: "Indicator count", Offset: 10, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Subfield code length", Offset: 11, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Base address of data", Offset: 12, Width: 5, CodeWidth: 5, FnType: "read"},
+	{Name: "Undefined character positions", Offset: 17, Width: 3, CodeWidth: 3, FnType: "read"},
+	{Name: "Length of the length-of-field portion", Offset: 20, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Length of the starting-character-position portion", Offset: 21, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Length of the implementation-defined portion", Offset: 22, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Undefined", Offset: 23, Width: 1, CodeWidth: 1, FnType: "read"},
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Holdings
 var holdingsLdrRecordStatus = map[string]string{
@@ -333,3 +412,23 @@ func parseHoldingsLdr(s string) (ldr LdrDesc) {
 
 	return ldr
 }
+
+// holdingsLdrElements defines the elements that are parsed by
+// parseHoldingsLdr
+var holdingsLdrElements = []ElementDef{
+	{Name: "Record length", Offset: 0, Width: 5, CodeWidth: 5, FnType: "read"},
+	{Name: "Record status", Offset: 5, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdingsLdrRecordStatus},
+	{Name: "Type of record", Offset: 6, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdingsLdrTypeOfRecord},
+	{Name: "Undefined character positions", Offset: 7, Width: 2, CodeWidth: 2, FnType: "read"},
+	{Name: "Character coding scheme", Offset: 9, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdingsLdrCharacterCodingScheme},
+	{Name: "Indicator count", Offset: 10, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Subfield code length", Offset: 11, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Base address of data", Offset: 12, Width: 5, CodeWidth: 5, FnType: "read"},
+	{Name: "Encoding level", Offset: 17, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdingsLdrEncodingLevel},
+	{Name: "Item information in record", Offset: 18, Width: 1, CodeWidth: 1, FnType: "lookup", Codes: holdingsLdrItemInformationInRecord},
+	{Name: "Undefined character position", Offset: 19, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Length of the length-of-field portion", Offset: 20, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Length of the starting-character-position portion", Offset: 21, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Length of the implementation-defined portion", Offset: 22, Width: 1, CodeWidth: 1, FnType: "read"},
+	{Name: "Undefined", Offset: 23, Width: 1, CodeWidth: 1, FnType: "read"},
+}
@@ -0,0 +1,124 @@
+// Copyright 2017-2018 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package details
+
+import (
+	"fmt"
+	"html"
+
+	"github.com/gsiems/go-marc21/pkg/marc21"
+)
+
+/*
+Annotated MARCXML is standard MARC 21 slim XML where the marc:leader and
+each marc:controlfield and marc:datafield is followed by a d:field
+element holding the decoded information for it. For the leader and
+decoded control fields the d:field has one empty d:element child for
+each decoded element (or, for elements that hold multiple codes, for
+each code) and, for control fields, the material type used to decode
+them (BK, MAP, etc.):
+
+    <marc:controlfield tag="008">980101s1998    nyua   jd ...</marc:controlfield>
+    <d:field tag="008" material="BK">
+        <d:element id="target_audience" name="Target audience"
+            positions="22" code="j" label="Juvenile" status="defined"/>
+        ...
+    </d:field>
+
+For data fields the d:field has the name of the field, where known
+(see DatafieldName):
+
+    <marc:datafield tag="245" ind1="1" ind2="0">...</marc:datafield>
+    <d:field tag="245" name="Title Statement"/>
+
+The MARC elements are left exactly as in MARC 21 slim XML (the leader
+and control fields have text content only) so the decoded information
+can be dropped by removing the elements of the d namespace, which is
+also required before validating against MARC21slim.xsd as the schema
+does not allow elements from other namespaces. The indicators and
+subfields of data fields are not labeled as there are, as yet, no
+definitions for them.
+*/
+
+// AnnotationNamespace is the XML namespace for the decoded information
+// in annotated MARCXML documents
+const AnnotationNamespace = "https://github.com/gsiems/go-marc21-details"
+
+// AnnotatedCollectionXMLHeader being the header (XML declaration and
+// opening collection tag) of an annotated MARCXML document
+const AnnotatedCollectionXMLHeader = `<?xml version="1.0" encoding="UTF-8"?>
+<marc:collection xmlns:marc="http://www.loc.gov/MARC21/slim"
+    xmlns:d="` + AnnotationNamespace + `"
+    xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"
+    xsi:schemaLocation="http://www.loc.gov/MARC21/slim http://www.loc.gov/standards/marcxml/schema/MARC21slim.xsd">
+`
+
+// AnnotatedCollectionXMLFooter being the footer (closing collection
+// tag) of an annotated MARCXML document
+const AnnotatedCollectionXMLFooter = marc21.CollectionXMLFooter
+
+// AnnotatedXML converts a record to annotated MARCXML
+func AnnotatedXML(rec marc21.Record) (ret string, err error) {
+
+	rd := DescribeRecord(rec)
+	format := rec.RecordFormat()
+
+	ret = "\t<marc:record>\n"
+
+	for _, fd := range rd.Fields {
+		if fd.Tag == "LDR" {
+			ret += fmt.Sprintf("\t\t<marc:leader>%s</marc:leader>\n", html.EscapeString(fd.Text))
+		} else {
+			ret += fmt.Sprintf("\t\t<marc:controlfield tag=%q>%s</marc:controlfield>\n", fd.Tag, html.EscapeString(fd.Text))
+		}
+		ret += annotatedField(fd)
+	}
+
+	for _, df := range rec.Datafields {
+		ret += fmt.Sprintf("\t\t<marc:datafield tag=%q ind1=%q ind2=%q>\n", df.GetTag(), df.GetInd1(), df.GetInd2())
+		for _, sf := range df.Subfields {
+			ret += fmt.Sprintf("\t\t\t<marc:subfield code=%q>%s</marc:subfield>\n", sf.GetCode(), html.EscapeString(sf.GetText()))
+		}
+		ret += "\t\t</marc:datafield>\n"
+
+		if name := DatafieldName(format, df.GetTag()); name != "" {
+			ret += fmt.Sprintf("\t\t<d:field tag=%s name=%s/>\n", xmlAttr(df.GetTag()), xmlAttr(name))
+		}
+	}
+	ret += "\t</marc:record>\n"
+
+	return ret, nil
+}
+
+// annotatedField returns the d:field element for a decoded leader or
+// control field. Control fields that have no element definitions (001,
+// 005, etc.) have no d:field.
+func annotatedField(fd FieldDesc) (ret string) {
+
+	if len(fd.Elements) == 0 {
+		return ""
+	}
+
+	ret = fmt.Sprintf("\t\t<d:field tag=%s", xmlAttr(fd.Tag))
+	if fd.Material != "" {
+		ret += fmt.Sprintf(" material=%s", xmlAttr(fd.Material))
+	}
+	ret += ">\n"
+
+	for _, e := range fd.Elements {
+		ret += fmt.Sprintf("\t\t\t<d:element id=%s name=%s positions=%s", xmlAttr(e.ID), xmlAttr(e.Name), xmlAttr(e.Positions))
+		if e.Seq > 0 {
+			ret += fmt.Sprintf(" seq=\"%d\"", e.Seq)
+		}
+		ret += fmt.Sprintf(" code=%s label=%s status=%s/>\n", xmlAttr(e.Code), xmlAttr(e.Label), xmlAttr(e.Status))
+	}
+
+	return ret + "\t\t</d:field>\n"
+}
+
+// xmlAttr returns the escaped and quoted value for an XML attribute
+func xmlAttr(s string) string {
+	return `"` + html.EscapeString(s) + `"`
+}
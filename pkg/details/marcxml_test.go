@@ -0,0 +1,115 @@
+// Copyright 2017-2018 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package details
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/gsiems/go-marc21/pkg/marc21"
+)
+
+func TestAnnotatedXML(t *testing.T) {
+
+	rec := testRecord(testBookLeader, "001 rec1", "008 "+testBook008)
+	rec.Datafields = append(rec.Datafields, &marc21.Datafield{
+		Tag:       "245",
+		Ind1:      "1",
+		Ind2:      "0",
+		Subfields: []*marc21.Subfield{{Code: "a", Text: "A title & more"}},
+	})
+
+	recxml, err := AnnotatedXML(rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := AnnotatedCollectionXMLHeader + recxml + AnnotatedCollectionXMLFooter
+
+	// The MARC elements have their MARC 21 slim content only
+	l, err := ParseXML([]byte(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(l) != 1 {
+		t.Fatalf("ParseXML returned %d records, want 1", len(l))
+	}
+	if l[0].Leader.Text != testBookLeader {
+		t.Errorf("leader = %q, want %q", l[0].Leader.Text, testBookLeader)
+	}
+	if got := l[0].GetControlfield("008"); got != testBook008 {
+		t.Errorf("008 = %q, want %q", got, testBook008)
+	}
+	if got := l[0].Datafields[0].Subfields[0].Text; got != "A title & more" {
+		t.Errorf("245 $a = %q, want %q", got, "A title & more")
+	}
+
+	// The annotations are in the d:field elements
+	var c struct {
+		Records []struct {
+			Fields []struct {
+				Tag      string `xml:"tag,attr"`
+				Material string `xml:"material,attr"`
+				Name     string `xml:"name,attr"`
+				Elements []struct {
+					ID     string `xml:"id,attr"`
+					Code   string `xml:"code,attr"`
+					Label  string `xml:"label,attr"`
+					Status string `xml:"status,attr"`
+				} `xml:"element"`
+			} `xml:"https://github.com/gsiems/go-marc21-details field"`
+		} `xml:"record"`
+	}
+	err = xml.Unmarshal([]byte(doc), &c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		tag      string
+		material string
+		name     string
+		id       string
+		code     string
+		label    string
+	}{
+		{"LDR", "", "", "encoding_level", " ", "Full level"},
+		{"008", "BK", "", "target_audience", "j", "Juvenile"},
+		{"245", "", "Title Statement", "", "", ""},
+	}
+
+	fields := c.Records[0].Fields
+	if len(fields) != len(tests) {
+		t.Fatalf("%d d:field elements, want %d", len(fields), len(tests))
+	}
+	for i, tt := range tests {
+		f := fields[i]
+		if f.Tag != tt.tag || f.Material != tt.material || f.Name != tt.name {
+			t.Errorf("d:field %d = %s/%s/%s, want %s/%s/%s", i, f.Tag, f.Material, f.Name, tt.tag, tt.material, tt.name)
+		}
+		if tt.id == "" {
+			if len(f.Elements) != 0 {
+				t.Errorf("d:field %s has %d elements, want none", f.Tag, len(f.Elements))
+			}
+			continue
+		}
+		var found bool
+		for _, e := range f.Elements {
+			if e.ID == tt.id {
+				found = true
+				if e.Code != tt.code || e.Label != tt.label || e.Status != StatusDefined {
+					t.Errorf("%s %s = %q %q %s, want %q %q defined", f.Tag, e.ID, e.Code, e.Label, e.Status, tt.code, tt.label)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("d:field %s has no %s element", f.Tag, tt.id)
+		}
+	}
+
+	if strings.Contains(doc, "d:material") {
+		t.Error("control fields have a d:material attribute")
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright 2017-2018 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package details
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+
+	"github.com/gsiems/go-marc21/pkg/marc21"
+)
+
+/*
+Records may be read from ISO 2709 (binary MARC) or from MARCXML. The
+MARCXML reader accepts collection and single record documents, with
+or without the MARC 21 slim namespace, and documents that wrap the
+records in other elements such as OAI-PMH harvests: any "record"
+element that is either in the MARC 21 slim namespace or in no
+namespace is read as a record. Records are read one at a time so large
+documents do not need to fit in memory.
+*/
+
+// MARCXMLNamespace is the XML namespace of MARCXML documents
+const MARCXMLNamespace = "http://www.loc.gov/MARC21/slim"
+
+// RecordReader reads the records of a MARC file one at a time. Next
+// returns io.EOF when there are no more records.
+type RecordReader interface {
+	Next() (*marc21.Record, error)
+}
+
+// XMLReader reads the records of a MARCXML document
+type XMLReader struct {
+	dec *xml.Decoder
+}
+
+// iso2709Reader reads the records of an ISO 2709 file
+type iso2709Reader struct {
+	r io.Reader
+}
+
+// NewRecordReader returns a reader for the records of either an ISO
+// 2709 or a MARCXML file. The format is determined by the first
+// character, other than white space and a byte order mark, of the
+// input: "<" for MARCXML.
+func NewRecordReader(r io.Reader) RecordReader {
+
+	br := bufio.NewReader(r)
+
+	isXML := IsMARCXML(br)
+	_, _ = br.Discard(leadingSpace(br))
+	if isXML {
+		return NewXMLReader(br)
+	}
+
+	return &iso2709Reader{r: br}
+}
+
+// IsMARCXML reports whether the input appears to be MARCXML, that is
+// whether its first character, other than white space and a byte order
+// mark, is "<". No input is consumed.
+func IsMARCXML(br *bufio.Reader) bool {
+	n := leadingSpace(br)
+	b, err := br.Peek(n + 1)
+	return err == nil && b[n] == '<'
+}
+
+// leadingSpace returns the number of white space and byte order mark
+// bytes at the start of the buffered input
+func leadingSpace(br *bufio.Reader) (n int) {
+
+	for {
+		b, err := br.Peek(n + 1)
+		if err != nil {
+			return n
+		}
+		switch b[n] {
+		case ' ', '\t', '\r', '\n':
+			n++
+			continue
+		case 0xEF:
+			if bom, err := br.Peek(n + 3); err == nil && bytes.Equal(bom[n:], []byte{0xEF, 0xBB, 0xBF}) {
+				n += 3
+				continue
+			}
+		}
+		return n
+	}
+}
+
+// Next returns the next record
+func (ir *iso2709Reader) Next() (*marc21.Record, error) {
+	return marc21.ParseNextRecord(ir.r)
+}
+
+// NewXMLReader returns a reader for the records of a MARCXML document
+func NewXMLReader(r io.Reader) *XMLReader {
+	return &XMLReader{dec: xml.NewDecoder(r)}
+}
+
+// Next returns the next record
+func (xr *XMLReader) Next() (*marc21.Record, error) {
+
+	for {
+		t, err := xr.dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		se, ok := t.(xml.StartElement)
+		if !ok || se.Name.Local != "record" {
+			continue
+		}
+		if se.Name.Space != MARCXMLNamespace && se.Name.Space != "" {
+			continue
+		}
+
+		var rec marc21.Record
+		err = xr.dec.DecodeElement(&rec, &se)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rec, nil
+	}
+}
+
+// ParseXML parses the records of a MARCXML document
+func ParseXML(data []byte) (l []*marc21.Record, err error) {
+
+	xr := NewXMLReader(bytes.NewReader(data))
+	for {
+		rec, err := xr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return l, err
+		}
+		l = append(l, rec)
+	}
+
+	if len(l) == 0 {
+		return l, errors.New("no MARCXML records found")
+	}
+
+	return l, nil
+}
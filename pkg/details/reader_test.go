@@ -0,0 +1,128 @@
+// Copyright 2017-2018 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package details
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestNewRecordReader(t *testing.T) {
+
+	rec1 := testRecord(testBookLeader, "001 rec1", "008 "+testBook008)
+	rec2 := testRecord(testBookLeader, "001 rec2")
+	iso, err := rec1.RecordAsMARC()
+	if err != nil {
+		t.Fatal(err)
+	}
+	iso2, err := rec2.RecordAsMARC()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plainRecord := func(cn string) string {
+		return `<record><leader>` + testBookLeader + `</leader><controlfield tag="001">` + cn + `</controlfield></record>`
+	}
+	marcRecord := func(cn string) string {
+		return `<marc:record><marc:leader>` + testBookLeader + `</marc:leader><marc:controlfield tag="001">` + cn + `</marc:controlfield></marc:record>`
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"ISO 2709", string(iso) + string(iso2), []string{"rec1", "rec2"}},
+		{"plain single record", plainRecord("rec1"), []string{"rec1"}},
+		{
+			"default namespace collection",
+			`<?xml version="1.0"?>` + "\n" + `<collection xmlns="` + MARCXMLNamespace + `">` + plainRecord("rec1") + plainRecord("rec2") + `</collection>`,
+			[]string{"rec1", "rec2"},
+		},
+		{
+			"prefixed namespace collection",
+			`<marc:collection xmlns:marc="` + MARCXMLNamespace + `">` + marcRecord("rec1") + marcRecord("rec2") + `</marc:collection>`,
+			[]string{"rec1", "rec2"},
+		},
+		{
+			"prefixed namespace single record",
+			`<marc:record xmlns:marc="` + MARCXMLNamespace + `"><marc:leader>` + testBookLeader + `</marc:leader><marc:controlfield tag="001">rec1</marc:controlfield></marc:record>`,
+			[]string{"rec1"},
+		},
+		{
+			"OAI-PMH with a byte order mark",
+			"\xEF\xBB\xBF\n" + `<OAI-PMH xmlns="http://www.openarchives.org/OAI/2.0/"><ListRecords>` +
+				`<record><header><identifier>oai:1</identifier></header><metadata>` +
+				`<marc:record xmlns:marc="` + MARCXMLNamespace + `"><marc:leader>` + testBookLeader + `</marc:leader><marc:controlfield tag="001">rec1</marc:controlfield></marc:record>` +
+				`</metadata></record></ListRecords></OAI-PMH>`,
+			[]string{"rec1"},
+		},
+		{"empty", "", nil},
+	}
+
+	for _, tt := range tests {
+		rr := NewRecordReader(strings.NewReader(tt.input))
+
+		var got []string
+		for {
+			rec, err := rr.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Errorf("%s: Next: %s", tt.name, err)
+				break
+			}
+			// The record length and base address are set by RecordAsMARC
+			if pluckBytes(rec.Leader.Text, 5, 7) != pluckBytes(testBookLeader, 5, 7) {
+				t.Errorf("%s: leader = %q, want %q", tt.name, rec.Leader.Text, testBookLeader)
+			}
+			got = append(got, rec.GetControlfield("001"))
+		}
+
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("%s: records = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestIsMARCXML(t *testing.T) {
+
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"<collection/>", true},
+		{"\xEF\xBB\xBF \r\n\t<record/>", true},
+		{"00428cam a2200181 i 4500", false},
+		{"  ", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		br := bufio.NewReader(strings.NewReader(tt.input))
+		if got := IsMARCXML(br); got != tt.want {
+			t.Errorf("IsMARCXML(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+		if br.Buffered() != len(tt.input) {
+			t.Errorf("IsMARCXML(%q) consumed input", tt.input)
+		}
+	}
+}
+
+func TestParseXML(t *testing.T) {
+
+	l, err := ParseXML([]byte(`<collection><record><leader>` + testBookLeader + `</leader></record></collection>`))
+	if err != nil || len(l) != 1 {
+		t.Errorf("ParseXML = %d records, %v, want 1 record", len(l), err)
+	}
+
+	_, err = ParseXML([]byte(`<collection xmlns="http://example.com/"><record/></collection>`))
+	if err == nil {
+		t.Error("ParseXML of a document having no MARCXML records did not fail")
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2017-2018 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package details
+
+import (
+	"strings"
+)
+
+/*
+Code search looks for codes and labels across all of the generated
+tables: "braille" finds the Form of item "f" of the books 008, the
+Class of braille writing codes of the tactile material 007 and so on,
+while the code "f" limited to the books 008 finds every element for
+which "f" is a defined code.
+
+Elements that are the same for all materials (the 006/00, 007/00 and
+the bibliography 008/00-17 and 008/35-39) are only reported once, as
+are the 006 elements for each material type.
+*/
+
+// SearchOptions limits a code search
+type SearchOptions struct {
+	// Format limits the search to a record format (marc21.Bibliography,
+	// etc.), zero searches all formats
+	Format int
+	// Tag limits the search to the leader ("LDR") or a control field
+	Tag string
+	// Material limits the search to a material, given as for FindMaterial
+	Material string
+	// Codes matches the query against the codes. Blanks may be given
+	// as "#".
+	Codes bool
+	// Labels matches the query against the labels (case-insensitive,
+	// anywhere in the label). If neither Codes nor Labels is set then
+	// both the codes and the labels are matched.
+	Labels bool
+}
+
+// CodeMatch contains a code found by SearchCodes
+type CodeMatch struct {
+	Format    string `json:"format"`
+	Tag       string `json:"tag"`
+	Material  string `json:"material,omitempty"`
+	Field     string `json:"field"`
+	Element   string `json:"element"`
+	Key       string `json:"key"`
+	Positions string `json:"positions"`
+	Code      string `json:"code"`
+	Label     string `json:"label"`
+}
+
+// SearchCodes returns the codes that match the query, ordered by
+// format, tag, material and position
+func SearchCodes(q string, opt SearchOptions) (l []CodeMatch) {
+
+	code := strings.Replace(q, "#", " ", -1)
+	label := strings.ToLower(q)
+	seen := make(map[string]bool)
+
+	if !opt.Codes && !opt.Labels {
+		opt.Codes = true
+		opt.Labels = true
+	}
+
+	for _, format := range Formats() {
+		if opt.Format != 0 && format != opt.Format {
+			continue
+		}
+		for _, tag := range Tags(format) {
+			if opt.Tag != "" && !strings.EqualFold(tag, opt.Tag) {
+				continue
+			}
+			for _, m := range Materials(format, tag) {
+				if opt.Material != "" && !m.matches(opt.Material) {
+					continue
+				}
+				fd, ok := GetFieldDef(format, tag, m.Code)
+				if !ok {
+					continue
+				}
+				for _, e := range fd.Elements {
+					key := fd.Key(e)
+					common := fd.Material != "" && !strings.Contains(key, "."+strings.ToLower(fd.Material)+".")
+					for _, c := range e.CodeList() {
+						if !(opt.Codes && c.Code == code) && !(opt.Labels && strings.Contains(strings.ToLower(c.Label), label)) {
+							continue
+						}
+						cm := CodeMatch{
+							Format:    fd.Format,
+							Tag:       fd.Tag,
+							Material:  fd.Material,
+							Field:     fd.Name,
+							Element:   e.Name,
+							Key:       key,
+							Positions: e.Positions(),
+							Code:      c.Code,
+							Label:     c.Label,
+						}
+						// The 006 definitions for the forms of material
+						// of a material type are the same
+						if seen[fd.Format+key+c.Code] {
+							continue
+						}
+						seen[fd.Format+key+c.Code] = true
+						if common {
+							cm.Material = ""
+							cm.Field = commonFieldNames[tag]
+						}
+						l = append(l, cm)
+					}
+				}
+			}
+		}
+	}
+
+	return l
+}
+
+// matches determines if the definition is for the code, material type
+// or name (see FindMaterial)
+func (m MaterialDef) matches(s string) bool {
+
+	if m.Code == s || (m.Material != "" && strings.EqualFold(m.Material, s)) {
+		return true
+	}
+
+	s = strings.ToLower(s)
+	name := strings.ToLower(m.Name)
+
+	return strings.HasPrefix(name, s) || strings.Contains(name, " "+s)
+}
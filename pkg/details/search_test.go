@@ -0,0 +1,47 @@
+// Copyright 2017-2018 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package details
+
+import (
+	"testing"
+
+	"github.com/gsiems/go-marc21/pkg/marc21"
+)
+
+func TestSearchCodes(t *testing.T) {
+
+	bk008 := SearchOptions{Format: marc21.Bibliography, Tag: "008", Material: "BK"}
+	codes, labels, both := bk008, bk008, bk008
+	codes.Codes = true
+	labels.Labels = true
+
+	tests := []struct {
+		name string
+		q    string
+		opt  SearchOptions
+		key  string
+		code string
+		want bool
+	}{
+		{"code", "j", codes, "008.bk.target_audience", "j", true},
+		{"code not label", "Juvenile", codes, "008.bk.target_audience", "j", false},
+		{"label", "juvenile", labels, "008.bk.target_audience", "j", true},
+		{"neither is code", "j", both, "008.bk.target_audience", "j", true},
+		{"neither is label", "juvenile", both, "008.bk.target_audience", "j", true},
+		{"blank as #", "#", codes, "008.bk.target_audience", " ", true},
+	}
+
+	for _, tt := range tests {
+		var got bool
+		for _, cm := range SearchCodes(tt.q, tt.opt) {
+			if cm.Key == tt.key && cm.Code == tt.code {
+				got = true
+			}
+		}
+		if got != tt.want {
+			t.Errorf("%s: SearchCodes(%q) has %s %q = %v, want %v", tt.name, tt.q, tt.key, tt.code, got, tt.want)
+		}
+	}
+}
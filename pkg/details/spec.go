@@ -0,0 +1,122 @@
+// Copyright 2017-2018 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package details
+
+import "sort"
+
+/*
+The specification is the complete set of leader and control field
+definitions in a form that can be exported (see cmd/marcspec.go and
+cmd/marcspec.schema.json) for use outside of Go.
+
+Note that the generated definitions do not currently contain any
+obsolete fields, elements or codes, so the specification does not
+either. The generators keep obsolete codes (see ElementDef.Obsolete) so
+that, once the definitions are regenerated, they are included and
+flagged as obsolete.
+*/
+
+// Spec contains the definitions for all record formats
+type Spec struct {
+	Formats []FormatSpec `json:"formats"`
+}
+
+// FormatSpec contains the field definitions for a record format
+type FormatSpec struct {
+	Name   string      `json:"name"`
+	Fields []FieldSpec `json:"fields"`
+}
+
+// FieldSpec contains one definition of the leader or of a control field
+type FieldSpec struct {
+	Tag      string        `json:"tag"`
+	Code     string        `json:"code"`
+	Material string        `json:"material,omitempty"`
+	Name     string        `json:"name"`
+	Length   int           `json:"length"`
+	Elements []ElementSpec `json:"elements"`
+}
+
+// ElementSpec contains the definition of an element
+type ElementSpec struct {
+	Key        string `json:"key"`
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Positions  string `json:"positions"`
+	Offset     int    `json:"offset"`
+	Width      int    `json:"width"`
+	CodeWidth  int    `json:"code_width"`
+	Type       string `json:"type"`
+	MultiCode  bool   `json:"multi_code"`
+	RangeLabel string `json:"range_label,omitempty"`
+	Codes      []Code `json:"codes,omitempty"`
+}
+
+// Specification returns the definitions for all record formats, tags
+// and materials
+func Specification() (s Spec) {
+
+	for _, format := range Formats() {
+		f := FormatSpec{Name: FormatName(format)}
+		for _, tag := range Tags(format) {
+			for _, m := range Materials(format, tag) {
+				fd, ok := GetFieldDef(format, tag, m.Code)
+				if !ok {
+					continue
+				}
+				f.Fields = append(f.Fields, fd.spec(m))
+			}
+		}
+		s.Formats = append(s.Formats, f)
+	}
+
+	return s
+}
+
+// spec returns the specification for a field definition
+func (fd FieldDef) spec(m MaterialDef) FieldSpec {
+
+	f := FieldSpec{
+		Tag:      fd.Tag,
+		Code:     m.Code,
+		Material: fd.Material,
+		Name:     m.Name,
+		Length:   fd.Length(),
+	}
+
+	for _, e := range fd.Elements {
+		f.Elements = append(f.Elements, fd.elementSpec(e))
+	}
+
+	return f
+}
+
+// elementSpec returns the specification for an element of the
+// definition
+func (fd FieldDef) elementSpec(e ElementDef) ElementSpec {
+	return ElementSpec{
+		Key:        fd.Key(e),
+		ID:         e.ID(),
+		Name:       e.Name,
+		Positions:  e.Positions(),
+		Offset:     e.Offset,
+		Width:      e.Width,
+		CodeWidth:  e.CodeWidth,
+		Type:       e.FnType,
+		MultiCode:  e.IsMultiCode(),
+		RangeLabel: e.RangeLabel,
+		Codes:      e.specCodes(),
+	}
+}
+
+// specCodes returns the defined and the obsolete codes of an element
+// ordered by code
+func (e ElementDef) specCodes() []Code {
+
+	l := append(e.CodeList(), e.ObsoleteCodeList()...)
+	sort.SliceStable(l, func(i, j int) bool { return l[i].Code < l[j].Code })
+
+	return l
+}
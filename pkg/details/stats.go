@@ -0,0 +1,439 @@
+// Copyright 2017-2018 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package details
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+
+	"github.com/gsiems/go-marc21/pkg/marc21"
+)
+
+/*
+Profiles gather statistics on the leader and control field elements of
+a set of records, broken down by record format and (for bibliography
+records) material type.
+
+Blank values are counted as blank and, where blank is not one of the
+codes of the element, also as undefined. Obsolete codes are counted as
+obsolete rather than as undefined, although the generated code tables
+(see cmd/gen-controlfield-auto.go) do not currently contain any, so
+obsolete codes are, for now, counted as undefined.
+*/
+
+// Profile gathers the statistics for a set of records
+type Profile struct {
+	records int
+	groups  map[string]*profileGroup
+}
+
+// ProfileGroup contains the statistics for the records of one format
+// and material type
+type ProfileGroup struct {
+	Format   string           `json:"format"`
+	Material string           `json:"material,omitempty"`
+	Records  int              `json:"records"`
+	Fields   []FieldProfile   `json:"fields"`
+	Elements []ElementProfile `json:"elements"`
+}
+
+// FieldProfile contains the statistics for the leader or a control
+// field. Length is the expected length of the field, Short and Long
+// are the number of occurrences that are shorter or longer than that.
+type FieldProfile struct {
+	Tag    string `json:"tag"`
+	Count  int    `json:"count"`
+	Length int    `json:"length,omitempty"`
+	Short  int    `json:"short"`
+	Long   int    `json:"long"`
+}
+
+// ElementProfile contains the statistics for an element. Values is the
+// number of values examined (elements that contain multiple codes
+// contribute one value per code). Codes is the frequency table of the
+// codes found, in descending order of frequency, and is not kept for
+// data elements (dates, etc.).
+type ElementProfile struct {
+	Key       string      `json:"key"`
+	Name      string      `json:"name"`
+	Positions string      `json:"positions"`
+	Values    int         `json:"values"`
+	Blank     int         `json:"blank"`
+	Fill      int         `json:"fill"`
+	Undefined int         `json:"undefined"`
+	Obsolete  int         `json:"obsolete"`
+	Missing   int         `json:"missing"`
+	Codes     []CodeCount `json:"codes,omitempty"`
+}
+
+// CodeCount is the number of times that a code was found
+type CodeCount struct {
+	Code   string `json:"code"`
+	Label  string `json:"label"`
+	Status string `json:"status"`
+	Count  int    `json:"count"`
+}
+
+type profileGroup struct {
+	format   string
+	material string
+	records  int
+	fields   map[string]*FieldProfile
+	elements map[string]*elementProfile
+}
+
+type elementProfile struct {
+	ElementProfile
+	tag    string
+	offset int
+	end    int
+	codes  map[string]*CodeCount
+}
+
+// NewProfile returns an empty profile
+func NewProfile() *Profile {
+	return &Profile{groups: make(map[string]*profileGroup)}
+}
+
+// Records returns the number of records added to the profile
+func (p *Profile) Records() int {
+	return p.records
+}
+
+// Add adds the leader and control field elements of a record to the
+// profile
+func (p *Profile) Add(rec marc21.Record) {
+
+	format := rec.RecordFormat()
+
+	var material string
+	if format == marc21.Bibliography {
+		material, _ = rec.BibliographyMaterialType()
+	}
+
+	gk := formatNames[format] + "/" + material
+	g, ok := p.groups[gk]
+	if !ok {
+		g = &profileGroup{
+			format:   formatNames[format],
+			material: material,
+			fields:   make(map[string]*FieldProfile),
+			elements: make(map[string]*elementProfile),
+		}
+		if g.format == "" {
+			g.format = "Unknown"
+		}
+		p.groups[gk] = g
+	}
+
+	p.records++
+	g.records++
+
+	fd, ok := LeaderDef(format)
+	g.addField("LDR", rec.Leader.Text, fd, ok)
+
+	for _, cf := range rec.Controlfields {
+		switch cf.Tag {
+		case "006", "007", "008":
+			fd, ok := controlfieldDef(format, material, cf)
+			g.addField(cf.Tag, cf.Text, fd, ok)
+		}
+	}
+}
+
+// addField adds the statistics for a leader or control field
+func (g *profileGroup) addField(tag, text string, fd FieldDef, ok bool) {
+
+	f, found := g.fields[tag]
+	if !found {
+		f = &FieldProfile{Tag: tag}
+		g.fields[tag] = f
+	}
+	f.Count++
+
+	if !ok {
+		return
+	}
+
+	// The expected length can differ between occurrences (007 fields
+	// for different categories of material) so check each against
+	// its own definition and report the length only when constant
+	n := fd.Length()
+	switch {
+	case f.Count == 1:
+		f.Length = n
+	case f.Length != n:
+		f.Length = 0
+	}
+
+	switch {
+	case len(text) < n:
+		f.Short++
+	case len(text) > n:
+		f.Long++
+	}
+
+	for _, ed := range fd.Decode(text) {
+		g.addElement(tag, ed)
+	}
+}
+
+// addElement adds a decoded element value
+func (g *profileGroup) addElement(tag string, ed ElementDesc) {
+
+	e, ok := g.elements[ed.Key]
+	if !ok {
+		e = &elementProfile{
+			ElementProfile: ElementProfile{Key: ed.Key, Name: ed.Name},
+			tag:            tag,
+			offset:         ed.Offset,
+			end:            ed.Offset + ed.Width,
+			codes:          make(map[string]*CodeCount),
+		}
+		g.elements[ed.Key] = e
+	}
+
+	if ed.Offset < e.offset {
+		e.offset = ed.Offset
+	}
+	if ed.Offset+ed.Width > e.end {
+		e.end = ed.Offset + ed.Width
+	}
+
+	e.Values++
+
+	if ed.Status == StatusMissing {
+		e.Missing++
+		return
+	}
+
+	if ed.Status != StatusFill && strings.Trim(ed.Code, " ") == "" {
+		e.Blank++
+	}
+
+	switch ed.Status {
+	case StatusFill:
+		e.Fill++
+	case StatusUndefined:
+		e.Undefined++
+	case StatusObsolete:
+		e.Obsolete++
+	}
+
+	if ed.Status == StatusData {
+		return
+	}
+
+	c, ok := e.codes[ed.Code]
+	if !ok {
+		c = &CodeCount{Code: ed.Code, Label: ed.Label, Status: ed.Status}
+		e.codes[ed.Code] = c
+	}
+	c.Count++
+}
+
+// Groups returns the statistics for each record format and material
+// type in format and material order. Fields are in leader, 006, 007,
+// 008 order and elements are in field and position order.
+func (p *Profile) Groups() (l []ProfileGroup) {
+
+	var gl []*profileGroup
+	for _, g := range p.groups {
+		gl = append(gl, g)
+	}
+	sort.Slice(gl, func(i, j int) bool {
+		if gl[i].format != gl[j].format {
+			return gl[i].format < gl[j].format
+		}
+		return gl[i].material < gl[j].material
+	})
+
+	for _, g := range gl {
+
+		pg := ProfileGroup{Format: g.format, Material: g.material, Records: g.records}
+
+		for _, tag := range []string{"LDR", "006", "007", "008"} {
+			if f, ok := g.fields[tag]; ok {
+				pg.Fields = append(pg.Fields, *f)
+			}
+		}
+
+		var el []*elementProfile
+		for _, e := range g.elements {
+			el = append(el, e)
+		}
+		sort.Slice(el, func(i, j int) bool { return el[i].less(el[j]) })
+
+		for _, e := range el {
+			ep := e.ElementProfile
+			ep.Positions = positions(e.offset, e.end-e.offset)
+			for _, c := range e.codes {
+				ep.Codes = append(ep.Codes, *c)
+			}
+			sort.Slice(ep.Codes, func(i, j int) bool {
+				if ep.Codes[i].Count != ep.Codes[j].Count {
+					return ep.Codes[i].Count > ep.Codes[j].Count
+				}
+				return ep.Codes[i].Code < ep.Codes[j].Code
+			})
+			pg.Elements = append(pg.Elements, ep)
+		}
+
+		l = append(l, pg)
+	}
+
+	return l
+}
+
+// less orders elements by field, position and material. The material
+// specific 008 elements are placed between the common elements that
+// precede and follow them.
+func (e *elementProfile) less(o *elementProfile) bool {
+
+	if e.tag != o.tag {
+		return tagRank(e.tag) < tagRank(o.tag)
+	}
+
+	eg, em := elementGroup008(e)
+	og, om := elementGroup008(o)
+	switch {
+	case eg != og:
+		return eg < og
+	case em != om:
+		return em < om
+	case e.offset != o.offset:
+		return e.offset < o.offset
+	}
+
+	return e.Key < o.Key
+}
+
+// elementGroup008 returns the ordering group and the material of an
+// element
+func elementGroup008(e *elementProfile) (group int, material string) {
+
+	p := strings.Split(e.Key, ".")
+	switch {
+	case len(p) == 3:
+		return 1, p[1]
+	case e.tag == "008" && e.offset >= 18:
+		return 2, ""
+	}
+
+	return 0, ""
+}
+
+func tagRank(tag string) int {
+	switch tag {
+	case "LDR":
+		return 0
+	case "006":
+		return 1
+	case "007":
+		return 2
+	}
+	return 3
+}
+
+// AsText returns the profile as a plain text report
+func (p *Profile) AsText() (ret string) {
+
+	ret = fmt.Sprintf("Records: %d\n", p.records)
+
+	for _, g := range p.Groups() {
+
+		ret += "\n" + strings.Repeat("=", 72) + "\n"
+		ret += fmt.Sprintf("Format: %s", g.Format)
+		if g.Material != "" {
+			ret += fmt.Sprintf("  Material: %s", g.Material)
+		}
+		ret += fmt.Sprintf("  Records: %d\n\n", g.Records)
+
+		ret += fmt.Sprintf("  %-5s %8s %8s %8s %8s\n", "Field", "Count", "Length", "Short", "Long")
+		for _, f := range g.Fields {
+			length := "varies"
+			if f.Length > 0 {
+				length = fmt.Sprintf("%d", f.Length)
+			}
+			ret += fmt.Sprintf("  %-5s %8d %8s %8d %8d\n", f.Tag, f.Count, length, f.Short, f.Long)
+		}
+
+		for _, e := range g.Elements {
+			ret += fmt.Sprintf("\n  %s (%s) %s\n", e.Key, e.Positions, e.Name)
+			ret += fmt.Sprintf("      values: %d  blank: %d  fill: %d  undefined: %d  obsolete: %d  missing: %d\n",
+				e.Values, e.Blank, e.Fill, e.Undefined, e.Obsolete, e.Missing)
+			for _, c := range e.Codes {
+				ret += fmt.Sprintf("      %-8s %8d  %s", strings.Replace(c.Code, " ", "#", -1), c.Count, c.Label)
+				if c.Status == StatusUndefined || c.Status == StatusObsolete {
+					ret += " [" + c.Status + "]"
+				}
+				ret += "\n"
+			}
+		}
+	}
+
+	return ret
+}
+
+// AsHTML returns the profile as an HTML report fragment that is
+// intended to be wrapped by HTMLReportHeader and HTMLReportFooter
+func (p *Profile) AsHTML() (ret string) {
+
+	ret = fmt.Sprintf("<h1>Fixed field profile</h1>\n<p>Records: %d</p>\n", p.records)
+
+	for _, g := range p.Groups() {
+
+		title := g.Format
+		if g.Material != "" {
+			title += " / " + g.Material
+		}
+
+		ret += "<div class=\"record\">\n"
+		ret += fmt.Sprintf("<h2>%s (%d records)</h2>\n", html.EscapeString(title), g.Records)
+
+		ret += "<table>\n<tr><th>Field</th><th>Count</th><th>Length</th><th>Short</th><th>Long</th></tr>\n"
+		for _, f := range g.Fields {
+			length := "varies"
+			if f.Length > 0 {
+				length = fmt.Sprintf("%d", f.Length)
+			}
+			ret += fmt.Sprintf("<tr><th>%s</th><td>%d</td><td>%s</td><td%s>%d</td><td%s>%d</td></tr>\n",
+				f.Tag, f.Count, length, flagClass(f.Short), f.Short, flagClass(f.Long), f.Long)
+		}
+		ret += "</table>\n"
+
+		ret += "<table>\n<tr><th>Element</th><th>Pos</th><th>Name</th><th>Values</th><th>Blank</th><th>Fill</th><th>Undefined</th><th>Obsolete</th><th>Missing</th><th>Codes</th></tr>\n"
+		for _, e := range g.Elements {
+			ret += fmt.Sprintf("<tr><td class=\"mono\">%s</td><td>%s</td><td>%s</td><td>%d</td><td>%d</td><td>%d</td><td%s>%d</td><td%s>%d</td><td%s>%d</td><td>",
+				html.EscapeString(e.Key), e.Positions, html.EscapeString(e.Name), e.Values, e.Blank, e.Fill,
+				flagClass(e.Undefined), e.Undefined, flagClass(e.Obsolete), e.Obsolete, flagClass(e.Missing), e.Missing)
+			for _, c := range e.Codes {
+				class := "mono"
+				if c.Status == StatusUndefined || c.Status == StatusObsolete {
+					class += " undefined"
+				}
+				ret += fmt.Sprintf("<span class=%q>%s</span> %d %s<br>",
+					class, html.EscapeString(strings.Replace(c.Code, " ", "#", -1)), c.Count, html.EscapeString(c.Label))
+			}
+			ret += "</td></tr>\n"
+		}
+		ret += "</table>\n"
+
+		ret += "</div>\n"
+	}
+
+	return ret
+}
+
+// flagClass returns the class attribute used to flag non-zero counts
+// of problems
+func flagClass(n int) string {
+	if n > 0 {
+		return " class=\"undefined\""
+	}
+	return ""
+}
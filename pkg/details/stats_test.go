@@ -0,0 +1,69 @@
+// Copyright 2017-2018 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package details
+
+import (
+	"testing"
+)
+
+func TestProfileCounts(t *testing.T) {
+
+	p := NewProfile()
+	// Leader/05 Record status has no blank code, Leader/17 Encoding
+	// level has
+	p.Add(testRecord("00428 am a2200181 i 4500", "008 "+testBook008))
+	p.Add(testRecord(testBookLeader, "008 "+testBook008))
+
+	// The generated tables have no obsolete codes so use a definition
+	// that does
+	g := p.groups["Bibliography/BK"]
+	fd := FieldDef{Tag: "006", Elements: []ElementDef{{
+		Name:      "Test element",
+		Offset:    0,
+		Width:     1,
+		CodeWidth: 1,
+		FnType:    "lookup",
+		Codes:     map[string]string{"a": "Defined"},
+		Obsolete:  map[string]string{"b": "Obsolete [OBSOLETE]"},
+	}}}
+	for _, s := range []string{"a", "b", "c", " ", "|", ""} {
+		g.addField("006", s, fd, true)
+	}
+
+	tests := []struct {
+		key       string
+		values    int
+		blank     int
+		fill      int
+		undefined int
+		obsolete  int
+		missing   int
+	}{
+		{"ldr.record_status", 2, 1, 0, 1, 0, 0},
+		{"ldr.encoding_level", 2, 2, 0, 0, 0, 0},
+		{"006.test_element", 6, 1, 1, 2, 1, 1},
+	}
+
+	got := make(map[string]ElementProfile)
+	for _, pg := range p.Groups() {
+		for _, e := range pg.Elements {
+			got[e.Key] = e
+		}
+	}
+
+	for _, tt := range tests {
+		e, ok := got[tt.key]
+		if !ok {
+			t.Errorf("%s: not profiled", tt.key)
+			continue
+		}
+		if e.Values != tt.values || e.Blank != tt.blank || e.Fill != tt.fill ||
+			e.Undefined != tt.undefined || e.Obsolete != tt.obsolete || e.Missing != tt.missing {
+			t.Errorf("%s: values/blank/fill/undefined/obsolete/missing = %d/%d/%d/%d/%d/%d, want %d/%d/%d/%d/%d/%d",
+				tt.key, e.Values, e.Blank, e.Fill, e.Undefined, e.Obsolete, e.Missing,
+				tt.values, tt.blank, tt.fill, tt.undefined, tt.obsolete, tt.missing)
+		}
+	}
+}
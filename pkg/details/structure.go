@@ -0,0 +1,419 @@
+// Copyright 2017-2018 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package details
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gsiems/go-marc21/pkg/marc21"
+)
+
+/*
+https://www.loc.gov/marc/specifications/specrecstruc.html
+
+Structural (ISO 2709) validation of the raw record bytes. Since a
+corrupt record length would cause marc21.NextRecord to lose its place
+in the file, ReadRawRecord splits records on the record terminator
+instead.
+*/
+
+const (
+	subfieldDelimiter = byte(0x1f)
+	fieldTerminator   = byte(0x1e)
+	recordTerminator  = byte(0x1d)
+)
+
+// structureRules is the list of structural validation rules
+var structureRules = []Rule{
+	{
+		ID:          "record-length",
+		Description: "Leader/00-04 must be the length of the record",
+		Severity:    SeverityError,
+		checkRaw:    checkRecordLength,
+	},
+	{
+		ID:          "base-address",
+		Description: "Leader/12-16 must be the position of the first field (the end of the directory)",
+		Severity:    SeverityError,
+		checkRaw:    checkBaseAddress,
+	},
+	{
+		ID:          "indicator-count",
+		Description: "Leader/10 must be 2 and data fields must start with two indicators",
+		Severity:    SeverityError,
+		checkRaw:    checkIndicatorCount,
+	},
+	{
+		ID:          "subfield-code-count",
+		Description: "Leader/11 must be 2",
+		Severity:    SeverityError,
+		checkRaw:    checkSubfieldCodeCount,
+	},
+	{
+		ID:          "entry-map",
+		Description: "Leader/20-23 must be 4500",
+		Severity:    SeverityError,
+		checkRaw:    checkEntryMap,
+	},
+	{
+		ID:          "directory",
+		Description: "Directory entries must be well formed and point to fields within the record",
+		Severity:    SeverityError,
+		checkRaw:    checkDirectory,
+	},
+	{
+		ID:          "field-terminator",
+		Description: "Each field must end with a field terminator",
+		Severity:    SeverityError,
+		checkRaw:    checkFieldTerminators,
+	},
+	{
+		ID:          "record-terminator",
+		Description: "The record must end with a record terminator",
+		Severity:    SeverityError,
+		checkRaw:    checkRecordTerminator,
+	},
+	{
+		ID:          "record-parse",
+		Description: "The record must be parsable",
+		Severity:    SeverityError,
+	},
+}
+
+// rawDirEntry being a directory entry of a raw record
+type rawDirEntry struct {
+	tag    string
+	length int
+	start  int
+	valid  bool
+}
+
+// ReadRawRecord reads the bytes of the next record, up to and
+// including the record terminator. The final record of the input is
+// returned even if it is missing the record terminator.
+func ReadRawRecord(r *bufio.Reader) ([]byte, error) {
+
+	b, err := r.ReadBytes(recordTerminator)
+	if err == io.EOF && len(b) > 0 {
+		return b, nil
+	}
+
+	return b, err
+}
+
+// ValidateStructure checks the ISO 2709 structure of the raw bytes of
+// a record using all of the structural rules
+func ValidateStructure(rawRec []byte) (l []Issue) {
+	for _, r := range structureRules {
+		if r.checkRaw == nil {
+			continue
+		}
+		for _, is := range r.checkRaw(rawRec) {
+			is.Rule = r.ID
+			is.Severity = r.Severity
+			l = append(l, is)
+		}
+	}
+	return l
+}
+
+// ValidateRaw validates the structure of the raw bytes of a record
+// and, if the record can be parsed, validates the parsed record. The
+// parsed record is also returned.
+func (v *Validator) ValidateRaw(rawRec []byte) (l []Issue, rec *marc21.Record) {
+
+	rec, err := parseRawRecord(rawRec)
+
+	cn := rawControlNumber(rawRec)
+
+	for _, r := range v.rules {
+		s := v.ruleSeverity(r)
+		if s == SeverityOff {
+			continue
+		}
+
+		var il []Issue
+		switch {
+		case r.checkRaw != nil:
+			il = r.checkRaw(rawRec)
+		case r.ID == "record-parse" && err != nil:
+			il = []Issue{{Message: fmt.Sprintf("record cannot be parsed: %s", err)}}
+		}
+
+		for _, is := range il {
+			is.ControlNumber = cn
+			is.Rule = r.ID
+			is.Severity = s
+			l = append(l, is)
+		}
+	}
+
+	if rec != nil {
+		l = append(l, v.Validate(*rec)...)
+	}
+
+	return l, rec
+}
+
+// parseRawRecord parses a raw record. marc21.ParseRecord assumes that
+// the record is well formed so any panic is converted to an error.
+func parseRawRecord(rawRec []byte) (rec *marc21.Record, err error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			rec = nil
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	if len(rawRec) < 25 {
+		return nil, fmt.Errorf("record is shorter than the leader")
+	}
+
+	return marc21.ParseRecord(rawRec)
+}
+
+// rawNumber returns the number in the specified bytes of a raw record
+func rawNumber(b []byte, offset, width int) (n int, ok bool) {
+	if offset+width > len(b) {
+		return 0, false
+	}
+	for _, c := range b[offset : offset+width] {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		n = n*10 + int(c-'0')
+	}
+	return n, true
+}
+
+// rawDirectory returns the directory entries of a raw record and the
+// position of the directory terminator (-1 if not found)
+func rawDirectory(b []byte) (l []rawDirEntry, end int) {
+
+	end = -1
+	i := 24
+	for ; i < len(b); i += 12 {
+		if b[i] == fieldTerminator || b[i] == recordTerminator {
+			break
+		}
+		if i+12 > len(b) {
+			break
+		}
+
+		var de rawDirEntry
+		de.tag = string(b[i : i+3])
+		var lok, sok bool
+		de.length, lok = rawNumber(b, i+3, 4)
+		de.start, sok = rawNumber(b, i+7, 5)
+		de.valid = lok && sok
+		l = append(l, de)
+	}
+
+	if i < len(b) && b[i] == fieldTerminator {
+		end = i
+	}
+
+	return l, end
+}
+
+// rawBase returns the base address of data of a raw record. The base
+// address is only returned if it agrees with the end of the directory
+// as otherwise the field positions cannot be relied on.
+func rawBase(b []byte) (base int, ok bool) {
+
+	base, ok = rawNumber(b, 12, 5)
+	if !ok {
+		return 0, false
+	}
+
+	_, end := rawDirectory(b)
+
+	return base, end >= 0 && base == end+1
+}
+
+// rawControlNumber returns the 001 of a raw record, if it can be found
+func rawControlNumber(b []byte) string {
+
+	base, ok := rawBase(b)
+	if !ok {
+		return ""
+	}
+
+	dir, _ := rawDirectory(b)
+	for _, de := range dir {
+		i := base + de.start
+		if de.tag == "001" && de.valid && de.length > 0 && i+de.length <= len(b) {
+			return strings.TrimRight(string(b[i:i+de.length]), string(fieldTerminator))
+		}
+	}
+
+	return ""
+}
+
+func checkRecordLength(b []byte) (l []Issue) {
+
+	n, ok := rawNumber(b, 0, 5)
+	switch {
+	case len(b) < 24:
+		return append(l, Issue{Tag: "LDR", Message: fmt.Sprintf("record is only %d bytes long", len(b))})
+	case !ok:
+		return append(l, Issue{Tag: "LDR", Positions: "00-04", Message: fmt.Sprintf("record length %q is not numeric", b[0:5])})
+	case n != len(b):
+		return append(l, Issue{Tag: "LDR", Positions: "00-04", Message: fmt.Sprintf("record length is %d, actual length is %d", n, len(b))})
+	}
+
+	return l
+}
+
+func checkBaseAddress(b []byte) (l []Issue) {
+
+	if len(b) < 24 {
+		return l
+	}
+
+	n, ok := rawNumber(b, 12, 5)
+	if !ok {
+		return append(l, Issue{Tag: "LDR", Positions: "12-16", Message: fmt.Sprintf("base address of data %q is not numeric", b[12:17])})
+	}
+
+	_, end := rawDirectory(b)
+	if end >= 0 && n != end+1 {
+		l = append(l, Issue{Tag: "LDR", Positions: "12-16", Message: fmt.Sprintf("base address of data is %d, the directory ends at %d", n, end+1)})
+	}
+
+	return l
+}
+
+func checkIndicatorCount(b []byte) (l []Issue) {
+
+	if len(b) < 24 {
+		return l
+	}
+	if b[10] != '2' {
+		l = append(l, Issue{Tag: "LDR", Positions: "10", Message: fmt.Sprintf("indicator count is %q, expected \"2\"", b[10:11])})
+	}
+
+	base, ok := rawBase(b)
+	if !ok {
+		return l
+	}
+
+	dir, _ := rawDirectory(b)
+	for _, de := range dir {
+		if !de.valid || isControlTag(de.tag) {
+			continue
+		}
+		i := base + de.start
+		if i+3 > len(b) {
+			continue
+		}
+		if b[i] == subfieldDelimiter || b[i+1] == subfieldDelimiter || b[i+2] != subfieldDelimiter {
+			l = append(l, Issue{Tag: de.tag, Message: "field does not start with two indicators and a subfield delimiter"})
+		}
+	}
+
+	return l
+}
+
+func checkSubfieldCodeCount(b []byte) (l []Issue) {
+	if len(b) >= 24 && b[11] != '2' {
+		l = append(l, Issue{Tag: "LDR", Positions: "11", Message: fmt.Sprintf("subfield code count is %q, expected \"2\"", b[11:12])})
+	}
+	return l
+}
+
+func checkEntryMap(b []byte) (l []Issue) {
+	if len(b) >= 24 && string(b[20:24]) != "4500" {
+		l = append(l, Issue{Tag: "LDR", Positions: "20-23", Message: fmt.Sprintf("entry map is %q, expected \"4500\"", b[20:24])})
+	}
+	return l
+}
+
+func checkDirectory(b []byte) (l []Issue) {
+
+	if len(b) < 24 {
+		return l
+	}
+
+	dir, end := rawDirectory(b)
+	if end < 0 {
+		return append(l, Issue{Tag: "LDR", Message: "directory is not terminated by a field terminator"})
+	}
+	if (end-24)%12 != 0 {
+		l = append(l, Issue{Tag: "LDR", Message: fmt.Sprintf("directory length %d is not a multiple of 12", end-24)})
+	}
+
+	base, ok := rawBase(b)
+	if !ok {
+		return l
+	}
+
+	for i, de := range dir {
+		n := i + 1
+		switch {
+		case !isTag(de.tag):
+			l = append(l, Issue{Tag: de.tag, Message: fmt.Sprintf("directory entry %d has an invalid tag %q", n, de.tag)})
+		case !de.valid:
+			l = append(l, Issue{Tag: de.tag, Message: fmt.Sprintf("directory entry %d has a non-numeric length or starting position", n)})
+		case de.length < 1:
+			l = append(l, Issue{Tag: de.tag, Message: fmt.Sprintf("directory entry %d has a zero length", n)})
+		case base+de.start+de.length > len(b)-1:
+			l = append(l, Issue{Tag: de.tag, Message: fmt.Sprintf("directory entry %d (start %d, length %d) extends past the end of the data", n, de.start, de.length)})
+		}
+	}
+
+	return l
+}
+
+func checkFieldTerminators(b []byte) (l []Issue) {
+
+	base, ok := rawBase(b)
+	if len(b) < 24 || !ok {
+		return l
+	}
+
+	dir, _ := rawDirectory(b)
+	for _, de := range dir {
+		i := base + de.start + de.length - 1
+		if !de.valid || de.length < 1 || i >= len(b) {
+			continue
+		}
+		if b[i] != fieldTerminator {
+			l = append(l, Issue{Tag: de.tag, Message: "field does not end with a field terminator"})
+		}
+	}
+
+	return l
+}
+
+func checkRecordTerminator(b []byte) (l []Issue) {
+	if len(b) == 0 || b[len(b)-1] != recordTerminator {
+		l = append(l, Issue{Tag: "LDR", Message: "record does not end with a record terminator"})
+	}
+	return l
+}
+
+// isTag determines if a tag consists of three ASCII letters or digits
+func isTag(tag string) bool {
+	if len(tag) != 3 {
+		return false
+	}
+	for i := 0; i < 3; i++ {
+		c := tag[i]
+		if !(c >= '0' && c <= '9') && !(c >= 'a' && c <= 'z') && !(c >= 'A' && c <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// isControlTag determines if a tag is for a control field
+func isControlTag(tag string) bool {
+	return len(tag) == 3 && tag[0] == '0' && tag[1] == '0'
+}
@@ -0,0 +1,361 @@
+// Copyright 2017-2018 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package details
+
+import (
+	"strings"
+
+	"github.com/gsiems/go-marc21/pkg/marc21"
+)
+
+const (
+	// SummaryTerse summarizes what the described material is
+	SummaryTerse = iota
+	// SummaryVerbose also summarizes the record itself and the
+	// contents and carriers of the described material
+	SummaryVerbose
+)
+
+// literaryFormNouns provides the noun to use for describing books
+// based on the 008/33 (Literary form) code
+var literaryFormNouns = map[string]string{
+	"1": "work of fiction",
+	"d": "drama",
+	"e": "collection of essays",
+	"f": "novel",
+	"h": "humorous work",
+	"i": "collection of letters",
+	"j": "collection of short stories",
+	"m": "work of mixed literary forms",
+	"p": "book of poetry",
+	"s": "collection of speeches",
+}
+
+// Summarize returns a short plain-language paragraph that describes a
+// record based on the contents of the leader and the 006, 007, and 008
+// control fields. Values that are blank, not coded, or otherwise
+// uninformative are skipped. The level is one of SummaryTerse or
+// SummaryVerbose.
+func Summarize(rec marc21.Record, level int) string {
+
+	ldr := ParseLeader(rec)
+
+	var parts []string
+
+	if rec.RecordFormat() == marc21.Bibliography {
+		parts = append(parts, summarizeBibliography(rec, ldr))
+	} else {
+		parts = append(parts, summarizeOther(rec, ldr))
+	}
+
+	if level >= SummaryVerbose {
+		if s := summarizeRecord(rec, ldr); s != "" {
+			parts = append(parts, s)
+		}
+		if s := summarizeContents(Parse008(rec)); s != "" {
+			parts = append(parts, s)
+		}
+		if s := summarizeCarriers(Parse006(rec), Parse007(rec)); s != "" {
+			parts = append(parts, s)
+		}
+	}
+
+	return strings.Join(parts, "; ") + "."
+}
+
+// summarizeBibliography describes what the material documented by a
+// bibliography record is, where it is from, and when
+func summarizeBibliography(rec marc21.Record, ldr LdrDesc) string {
+
+	p8 := Parse008(rec)
+	mt, _ := rec.BibliographyMaterialType()
+
+	var words []string
+
+	if cv, ok := p8.first("Form of item"); ok && isInformative(cv) {
+		words = append(words, strings.Replace(strings.ToLower(cv.Label), " ", "-", -1))
+	}
+	if cv, ok := p8.first("Target audience"); ok && isInformative(cv) {
+		words = append(words, strings.ToLower(cv.Label))
+	}
+	words = append(words, bibliographyNoun(mt, ldr, p8))
+
+	s := strings.Join(words, " ")
+	s = withArticle(s)
+
+	if cv, ok := p8.first("Language"); ok {
+		switch cv.Code {
+		case "mul":
+			s += " in multiple languages"
+		case "zxx":
+			s += " with no linguistic content"
+		case "und":
+		default:
+			if isInformative(cv) {
+				if n, ok := languageNames[cv.Code]; ok {
+					s += " in " + n
+				} else {
+					s += " in language " + cv.Code
+				}
+			}
+		}
+	}
+
+	var pub []string
+	if place := placeOfPublication(rec); place != "" {
+		pub = append(pub, "in "+place)
+	}
+	if cv, ok := p8.first("Date 1"); ok {
+		if d := dateDescription(cv.Code); d != "" {
+			pub = append(pub, "in "+d)
+		}
+	}
+	if len(pub) > 0 {
+		s += ", published " + strings.Join(pub, " ")
+	}
+
+	return s
+}
+
+// bibliographyNoun returns the noun that best describes the material
+// type of a bibliography record
+func bibliographyNoun(mt string, ldr LdrDesc, p8 Cf008Desc) string {
+
+	var element string
+
+	switch mt {
+	case "BK":
+		if cv, ok := p8.first("Literary form"); ok {
+			if n, ok := literaryFormNouns[cv.Code]; ok {
+				return n
+			}
+		}
+		if cv, ok := ldr.get("Type of record"); ok && cv.Code == "a" {
+			return "book"
+		}
+	case "CF":
+		element = "Type of computer file"
+	case "MP":
+		element = "Type of cartographic material"
+	case "CR":
+		element = "Type of continuing resource"
+	case "VM":
+		element = "Type of visual material"
+	}
+
+	if element != "" {
+		if cv, ok := p8.first(element); ok && isInformative(cv) {
+			return strings.ToLower(cv.Label)
+		}
+	}
+
+	if cv, ok := ldr.get("Type of record"); ok && cv.Label != "" {
+		return strings.ToLower(cv.Label)
+	}
+
+	return "resource"
+}
+
+// summarizeOther describes a non-bibliography record
+func summarizeOther(rec marc21.Record, ldr LdrDesc) string {
+
+	s := withArticle(strings.ToLower(rec.RecordFormatName()) + " record")
+
+	var cv CodeValue
+	switch rec.RecordFormat() {
+	case marc21.Authority:
+		cv, _ = Parse008(rec).first("Kind of record")
+	case marc21.Community:
+		cv, _ = ldr.get("Kind of data")
+	default:
+		cv, _ = ldr.get("Type of record")
+	}
+
+	if isInformative(cv) {
+		s += " (" + strings.ToLower(cv.Label) + ")"
+	}
+
+	return s
+}
+
+// summarizeRecord describes the record itself (encoding level and
+// cataloging rules)
+func summarizeRecord(rec marc21.Record, ldr LdrDesc) string {
+
+	var words []string
+
+	if cv, ok := ldr.get("Encoding level"); ok && isInformative(cv) {
+		l := strings.ToLower(cv.Label)
+		if strings.Contains(l, ",") {
+			words = append(words, "record encoding level: "+l)
+		} else {
+			l = strings.Replace(l, " level", "-level", 1)
+			words = append(words, l+" record")
+		}
+	}
+
+	// The description conventions (RDA, DCRMB, etc.) are only found in
+	// the 040 $e. Fall back to the leader Descriptive cataloging form.
+	var rules string
+	for _, df := range rec.GetDatafields("040") {
+		for _, sf := range df.GetSubfields("e") {
+			if rules == "" {
+				rules = strings.ToUpper(sf.GetText()) + " cataloging"
+			}
+		}
+	}
+	if rules == "" {
+		if cv, ok := ldr.get("Descriptive cataloging form"); ok && isInformative(cv) {
+			rules = cv.Label
+		}
+	}
+	if rules != "" {
+		words = append(words, rules)
+	}
+
+	return strings.Join(words, ", ")
+}
+
+// summarizeContents describes the contents of the material from the
+// 008 contents related elements
+func summarizeContents(p8 Cf008Desc) string {
+
+	var items []string
+
+	for _, name := range []string{"Nature of entire work", "Nature of contents", "Accompanying matter", "Illustrations"} {
+		for _, cv := range p8.all(name) {
+			if isInformative(cv) {
+				items = append(items, strings.ToLower(cv.Label))
+			}
+		}
+	}
+	if cv, ok := p8.first("Index"); ok && cv.Code == "1" {
+		items = append(items, "an index")
+	}
+	if cv, ok := p8.first("Conference publication"); ok && cv.Code == "1" {
+		items = append(items, "conference proceedings")
+	}
+
+	if len(items) == 0 {
+		return ""
+	}
+
+	return "contains " + joinList(items)
+}
+
+// summarizeCarriers describes the additional material characteristics
+// (006) and the physical carriers (007) of the material
+func summarizeCarriers(p6 Cf008Desc, p7 []Cf007Desc) string {
+
+	var words []string
+
+	var forms []string
+	for _, cv := range p6.all("Form of material") {
+		if isInformative(cv) {
+			forms = append(forms, strings.ToLower(cv.Label))
+		}
+	}
+	if len(forms) > 0 {
+		words = append(words, "also has characteristics of "+joinList(forms))
+	}
+
+	var carriers []string
+	for _, cf := range p7 {
+		cv, ok := cf["(01/01) Specific material designation"]
+		if !ok || !isInformative(cv) {
+			cv = cf["(00/01) Category of material"]
+		}
+		if isInformative(cv) {
+			carriers = append(carriers, strings.ToLower(cv.Label))
+		}
+	}
+	if len(carriers) > 0 {
+		words = append(words, "carriers: "+joinList(carriers))
+	}
+
+	return strings.Join(words, "; ")
+}
+
+// placeOfPublication returns the place of publication from the 264
+// (publication) or 260 field of the record.
+func placeOfPublication(rec marc21.Record) string {
+
+	for _, df := range rec.GetDatafields("264") {
+		if df.GetInd2() != "1" {
+			continue
+		}
+		for _, sf := range df.GetSubfields("a") {
+			return strings.Trim(sf.GetText(), " :;,/[]")
+		}
+	}
+	for _, df := range rec.GetDatafields("260") {
+		for _, sf := range df.GetSubfields("a") {
+			return strings.Trim(sf.GetText(), " :;,/[]")
+		}
+	}
+
+	return ""
+}
+
+// dateDescription returns a readable form of an 008 date (Date 1 or
+// Date 2) element for dates that are either known or have an unknown
+// last digit.
+func dateDescription(d string) string {
+
+	if len(d) != 4 {
+		return ""
+	}
+	if strings.Trim(d, "0123456789") == "" {
+		return d
+	}
+	if strings.Trim(d[:3], "0123456789") == "" && d[3] == 'u' {
+		return "the " + d[:3] + "0s"
+	}
+
+	return ""
+}
+
+// isInformative indicates whether or not a decoded value says anything
+// worth reporting as opposed to being missing, not coded, unknown, or
+// a "no such thing" value.
+func isInformative(cv CodeValue) bool {
+
+	if cv.Code == "" || strings.Trim(cv.Code, "|") == "" {
+		return false
+	}
+	if cv.Label == "" {
+		return strings.TrimSpace(cv.Code) != ""
+	}
+	for _, p := range []string{"No ", "Not ", "None ", "Unknown", "Unspecified"} {
+		if strings.HasPrefix(cv.Label, p) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// withArticle prefixes a phrase with the appropriate indefinite article
+func withArticle(s string) string {
+	if s == "" {
+		return s
+	}
+	a := "A "
+	if strings.ContainsAny(s[:1], "aeiouAEIOU") {
+		a = "An "
+	}
+	return a + s
+}
+
+// joinList joins a list of items as an English list ("a, b and c")
+func joinList(items []string) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+}
@@ -0,0 +1,55 @@
+// Copyright 2017-2018 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package details
+
+import (
+	"testing"
+
+	"github.com/gsiems/go-marc21/pkg/marc21"
+)
+
+func TestSummarize(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		rec     marc21.Record
+		terse   string
+		verbose string
+	}{
+		{
+			"no 008",
+			testRecord(testBookLeader, "001 rec1"),
+			"A book.",
+			"A book; full-level record, ISBD punctuation included.",
+		},
+		{
+			"short 008",
+			testRecord(testBookLeader, "008 980101s1998"),
+			"A book, published in 1998.",
+			"A book, published in 1998; full-level record, ISBD punctuation included.",
+		},
+		{
+			"empty 006",
+			testRecord(testBookLeader, "006", "008 "+testBook008),
+			"A large-print juvenile work of fiction in English, published in 1998.",
+			"A large-print juvenile work of fiction in English, published in 1998; full-level record, ISBD punctuation included; contains illustrations and an index.",
+		},
+		{
+			"book",
+			testRecord(testBookLeader, "008 "+testBook008),
+			"A large-print juvenile work of fiction in English, published in 1998.",
+			"A large-print juvenile work of fiction in English, published in 1998; full-level record, ISBD punctuation included; contains illustrations and an index.",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := Summarize(tt.rec, SummaryTerse); got != tt.terse {
+			t.Errorf("%s: Summarize terse = %q, want %q", tt.name, got, tt.terse)
+		}
+		if got := Summarize(tt.rec, SummaryVerbose); got != tt.verbose {
+			t.Errorf("%s: Summarize verbose = %q, want %q", tt.name, got, tt.verbose)
+		}
+	}
+}
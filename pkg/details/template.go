@@ -0,0 +1,226 @@
+// Copyright 2017-2018 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package details
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gsiems/go-marc21/pkg/marc21"
+)
+
+/*
+Templates are the default leader, 006, 007 and 008 values for a new
+record, much like the workforms of a cataloging client. They are built
+with FieldDef.Encode so that every element is set to a code that is
+defined in the generated tables; elements that are not otherwise given
+a value get blanks where blank is a defined code and fill characters
+where it is not.
+
+The leader of a new record has a record status of "n" (new), a
+character coding scheme of "a" (UCS/Unicode) and, for bibliography
+records, a descriptive cataloging form of "i" (ISBD punctuation
+included). The type of record and bibliographic level follow from the
+material type:
+
+	BK  Books                  a m
+	CF  Computer files         m m
+	MP  Maps                   e m
+	MU  Music                  c m
+	CR  Continuing resources   a s
+	VM  Visual materials       g m
+	MX  Mixed materials        p c
+
+Holdings templates are for single-part item holdings ("x") and
+authority templates for authorized headings ("z").
+
+The local defaults (cataloging source, language, country) are applied
+to the elements of the 008 that exist for the format; any other element
+may be set by key (see TemplateDefaults.Values).
+*/
+
+// TemplateDefaults contains the local defaults for templates
+type TemplateDefaults struct {
+	// CatalogingSource is the 008/39 Cataloging source code ("d", "c", etc.)
+	CatalogingSource string
+	// Language is the MARC language code for the bibliography 008/35-37
+	// and holdings 008/22-24
+	Language string
+	// Country is the MARC country code for the bibliography 008/15-17
+	Country string
+	// Date is the date entered on file, the current date if not set
+	Date time.Time
+	// Values contains the values for any other elements keyed by element
+	// key ("ldr.encoding_level", "008.bk.target_audience") or by tag and
+	// element ID ("008.target_audience"). Values are given as codes or
+	// labels.
+	Values map[string]string
+}
+
+// Template contains the default leader and control field values for a
+// record format and material type
+type Template struct {
+	Format   string `json:"format"`
+	Material string `json:"material,omitempty"`
+	Name     string `json:"name"`
+	Leader   string `json:"leader"`
+	Cf008    string `json:"008"`
+}
+
+// templateLeaders contains the leader values for new records by format
+// and material type
+var templateLeaders = map[int]map[string]map[string]string{
+	marc21.Bibliography: {
+		"BK": {"type_of_record": "a", "bibliographic_level": "m"},
+		"CF": {"type_of_record": "m", "bibliographic_level": "m"},
+		"MP": {"type_of_record": "e", "bibliographic_level": "m"},
+		"MU": {"type_of_record": "c", "bibliographic_level": "m"},
+		"CR": {"type_of_record": "a", "bibliographic_level": "s"},
+		"VM": {"type_of_record": "g", "bibliographic_level": "m"},
+		"MX": {"type_of_record": "p", "bibliographic_level": "c"},
+	},
+	marc21.Holdings: {
+		"": {"type_of_record": "x", "encoding_level": "u", "item_information_in_record": "n"},
+	},
+	marc21.Authority: {
+		"": {"type_of_record": "z", "encoding_level": "n"},
+	},
+}
+
+// NewTemplate returns the template for the specified record format
+// (marc21.Bibliography, marc21.Holdings or marc21.Authority) and, for
+// bibliography records, material type ("BK", "CF", "MP", "MU", "CR",
+// "VM" or "MX").
+func NewTemplate(format int, material string, d TemplateDefaults) (t Template, err error) {
+
+	lv, ok := templateLeaders[format][material]
+	if !ok {
+		return t, fmt.Errorf("no template for %s %q", formatNames[format], material)
+	}
+
+	ld, _ := LeaderDef(format)
+	cd, ok := Cf008Def(format, material)
+	if !ok {
+		return t, fmt.Errorf("no 008 definition for %s %q", formatNames[format], material)
+	}
+
+	values := map[string]string{
+		"record_status":           "n",
+		"character_coding_scheme": "a",
+	}
+	if format == marc21.Bibliography {
+		values["descriptive_cataloging_form"] = "i"
+	}
+	for k, v := range lv {
+		values[k] = v
+	}
+
+	t.Leader, err = ld.Encode(ld.templateValues(values, d))
+	if err != nil {
+		return t, err
+	}
+
+	t.Cf008, err = cd.Encode(cd.templateValues(d.cf008Values(), d))
+	if err != nil {
+		return t, err
+	}
+
+	t.Format = ld.Format
+	t.Material = material
+	t.Name = cd.Name
+	if material == "" {
+		t.Name = ld.Format
+	}
+
+	return t, nil
+}
+
+// Template006 returns the bibliography 006 template for the specified
+// 006/00 (Form of material) code
+func Template006(formOfMaterial string, d TemplateDefaults) (string, error) {
+
+	fd, ok := Cf006Def(formOfMaterial)
+	if !ok {
+		return "", fmt.Errorf("no 006 definition for form of material %q", formOfMaterial)
+	}
+
+	return fd.Encode(fd.templateValues(nil, d))
+}
+
+// Template007 returns the 007 template for the specified record format
+// and 007/00 (Category of material) code
+func Template007(format int, category string, d TemplateDefaults) (string, error) {
+
+	fd, ok := Cf007Def(format, category)
+	if !ok {
+		return "", fmt.Errorf("no 007 definition for %s category of material %q", formatNames[format], category)
+	}
+
+	return fd.Encode(fd.templateValues(nil, d))
+}
+
+// cf008Values returns the 008 values for the local defaults. Values for
+// elements that the 008 does not have are dropped by templateValues.
+func (d TemplateDefaults) cf008Values() map[string]string {
+
+	date := d.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	values := map[string]string{
+		"date_entered_on_file": date.Format("060102"),
+	}
+	if d.CatalogingSource != "" {
+		values["cataloging_source"] = d.CatalogingSource
+	}
+	if d.Language != "" {
+		values["language"] = d.Language
+	}
+	if d.Country != "" {
+		// Two character country codes are blank filled
+		values["place_of_publication_production_or_execution"] = fmt.Sprintf("%-3s", d.Country)
+	}
+
+	return values
+}
+
+// templateValues returns the values for the elements of the definition,
+// being the template values followed by any of the default values for
+// the field
+func (fd FieldDef) templateValues(values map[string]string, d TemplateDefaults) map[string]string {
+
+	ids := make(map[string]bool)
+	for _, e := range fd.Elements {
+		ids[e.ID()] = true
+	}
+
+	l := make(map[string]string)
+	for k, v := range values {
+		if ids[k] {
+			l[k] = v
+		}
+	}
+
+	// Values for other fields are skipped, values for unknown elements
+	// of the field are passed on so that Encode rejects them
+	prefix := strings.ToLower(fd.Tag) + "."
+	for k, v := range d.Values {
+		if !strings.HasPrefix(strings.ToLower(k), prefix) {
+			continue
+		}
+		id := k[len(prefix):]
+		if strings.Contains(id, ".") {
+			if m := strings.ToLower(fd.Material) + "."; !strings.HasPrefix(strings.ToLower(id), m) {
+				continue
+			}
+			id = id[strings.Index(id, ".")+1:]
+		}
+		l[id] = v
+	}
+
+	return l
+}
@@ -0,0 +1,426 @@
+// Copyright 2017-2018 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package details
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/gsiems/go-marc21/pkg/marc21"
+)
+
+/*
+Validation checks the leader and control fields of records against the
+element definitions. Each check is a rule with an ID and a default
+severity. Rules may be disabled, and their severity changed, using a
+configuration file consisting of one rule per line:
+
+	# Blank lines and lines starting with # are ignored
+	undefined-code   warning
+	fill-in-leader   off
+
+where the second column is one of "error", "warning", "info" or "off".
+*/
+
+// Severity being the severity of a validation issue
+type Severity int
+
+const (
+	// SeverityOff indicates that the rule is disabled
+	SeverityOff Severity = iota
+	// SeverityInfo is for issues that are informational only
+	SeverityInfo
+	// SeverityWarning is for issues that should be reviewed
+	SeverityWarning
+	// SeverityError is for issues that are definitely wrong
+	SeverityError
+)
+
+var severityNames = map[Severity]string{
+	SeverityOff:     "off",
+	SeverityInfo:    "info",
+	SeverityWarning: "warning",
+	SeverityError:   "error",
+}
+
+// Issue being a single problem found by validation
+type Issue struct {
+	ControlNumber string   `json:"control_number"`
+	Tag           string   `json:"tag"`
+	Positions     string   `json:"positions,omitempty"`
+	Rule          string   `json:"rule"`
+	Severity      Severity `json:"severity"`
+	Message       string   `json:"message"`
+}
+
+// Rule being a validation rule
+type Rule struct {
+	ID          string
+	Description string
+	Severity    Severity
+	check       func(rec marc21.Record, rd RecordDesc) []Issue
+	checkRaw    func(rawRec []byte) []Issue
+}
+
+// Validator validates records using a set of rules
+type Validator struct {
+	rules    []Rule
+	severity map[string]Severity
+}
+
+// validationRules is the list of available rules
+var validationRules = []Rule{
+	{
+		ID:          "undefined-code",
+		Description: "Element codes must be defined for the element",
+		Severity:    SeverityError,
+		check:       checkUndefinedCodes,
+	},
+	{
+		ID:          "obsolete-code",
+		Description: "Element codes should not be obsolete",
+		Severity:    SeverityWarning,
+		check:       checkObsoleteCodes,
+	},
+	{
+		ID:          "field-length",
+		Description: "The leader, 006 and 008 must have the length defined for the record format and material type",
+		Severity:    SeverityError,
+		check:       checkFieldLengths,
+	},
+	{
+		ID:          "fill-in-leader",
+		Description: "Fill characters are not allowed in the leader",
+		Severity:    SeverityError,
+		check:       checkLeaderFill,
+	},
+	{
+		ID:          "record-format",
+		Description: "Leader/06 must identify the record format and, for bibliography records, Leader/06-07 the material type",
+		Severity:    SeverityError,
+		check:       checkRecordFormat,
+	},
+	{
+		ID:          "material-type",
+		Description: "The 006 form of material and 007 category of material must be defined",
+		Severity:    SeverityError,
+		check:       checkMaterialTypes,
+	},
+	{
+		ID:          "missing-008",
+		Description: "Records should have an 008",
+		Severity:    SeverityWarning,
+		check:       checkMissing008,
+	},
+}
+
+// String returns the name of the severity
+func (s Severity) String() string {
+	return severityNames[s]
+}
+
+// MarshalText marshals the severity as its name
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// ParseSeverity returns the severity for a name ("error", "warning",
+// "info" or "off")
+func ParseSeverity(name string) (Severity, error) {
+	for s, n := range severityNames {
+		if strings.EqualFold(name, n) {
+			return s, nil
+		}
+	}
+	return SeverityOff, fmt.Errorf("invalid severity %q", name)
+}
+
+// NewValidator returns a validator with all rules enabled at their
+// default severity
+func NewValidator() *Validator {
+
+	var l []Rule
+	l = append(l, structureRules...)
+	l = append(l, validationRules...)
+	l = append(l, crossFieldRules...)
+	l = append(l, consistencyRules...)
+
+	return &Validator{rules: l, severity: make(map[string]Severity)}
+}
+
+// Rules returns the rules of the validator with their current
+// severities
+func (v *Validator) Rules() (l []Rule) {
+	for _, r := range v.rules {
+		r.Severity = v.ruleSeverity(r)
+		l = append(l, r)
+	}
+	return l
+}
+
+// SetSeverity sets the severity of a rule. SeverityOff disables the
+// rule.
+func (v *Validator) SetSeverity(id string, s Severity) error {
+	for _, r := range v.rules {
+		if r.ID == id {
+			v.severity[id] = s
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown rule %q", id)
+}
+
+// LoadConfig reads the rule severities from a configuration file
+func (v *Validator) LoadConfig(filename string) error {
+
+	fi, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer fi.Close()
+
+	return v.ReadConfig(fi)
+}
+
+// ReadConfig reads the rule severities from a configuration
+func (v *Validator) ReadConfig(r io.Reader) error {
+
+	scanner := bufio.NewScanner(r)
+	var n int
+	for scanner.Scan() {
+		n++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		f := strings.Fields(line)
+		if len(f) != 2 {
+			return fmt.Errorf("config line %d: expected a rule and a severity", n)
+		}
+
+		s, err := ParseSeverity(f[1])
+		if err != nil {
+			return fmt.Errorf("config line %d: %s", n, err)
+		}
+
+		err = v.SetSeverity(f[0], s)
+		if err != nil {
+			return fmt.Errorf("config line %d: %s", n, err)
+		}
+	}
+
+	return scanner.Err()
+}
+
+// Validate validates a record using the enabled rules. The structural
+// rules require the raw record bytes and are only applied by
+// ValidateRaw.
+func (v *Validator) Validate(rec marc21.Record) (l []Issue) {
+
+	rd := DescribeRecord(rec)
+
+	for _, r := range v.rules {
+		s := v.ruleSeverity(r)
+		if s == SeverityOff || r.check == nil {
+			continue
+		}
+		for _, is := range r.check(rec, rd) {
+			is.ControlNumber = rd.ControlNumber
+			is.Rule = r.ID
+			is.Severity = s
+			l = append(l, is)
+		}
+	}
+
+	return l
+}
+
+func (v *Validator) ruleSeverity(r Rule) Severity {
+	if s, ok := v.severity[r.ID]; ok {
+		return s
+	}
+	return r.Severity
+}
+
+// String returns the issue in lint style
+func (is Issue) String() string {
+
+	loc := is.Tag
+	if is.Positions != "" {
+		loc += "/" + is.Positions
+	}
+
+	return fmt.Sprintf("%s %s %s: %s (%s)", is.ControlNumber, loc, is.Severity, is.Message, is.Rule)
+}
+
+// checkUndefinedCodes reports element codes that are not defined.
+// The holdings 008/22-24 Language code table only lists the special
+// values (blanks, "und") so any MARC language code is accepted there.
+func checkUndefinedCodes(rec marc21.Record, rd RecordDesc) (l []Issue) {
+	for _, fd := range rd.Fields {
+		for _, e := range fd.Elements {
+			if e.Status == StatusUndefined && !(e.Name == "Language" && isLanguageCode(e.Code)) {
+				l = append(l, Issue{
+					Tag:       fd.Tag,
+					Positions: e.Positions,
+					Message:   fmt.Sprintf("undefined code %q for %s", strings.Replace(e.Code, " ", "#", -1), e.Name),
+				})
+			}
+		}
+	}
+	return l
+}
+
+// checkObsoleteCodes reports element codes that are obsolete
+func checkObsoleteCodes(rec marc21.Record, rd RecordDesc) (l []Issue) {
+	for _, fd := range rd.Fields {
+		for _, e := range fd.Elements {
+			if e.Status == StatusObsolete {
+				l = append(l, Issue{
+					Tag:       fd.Tag,
+					Positions: e.Positions,
+					Message:   fmt.Sprintf("obsolete code %q for %s", strings.Replace(e.Code, " ", "#", -1), e.Name),
+				})
+			}
+		}
+	}
+	return l
+}
+
+// checkFieldLengths reports fields that are too short or too long. The
+// 007 is checked by the 007-length rule.
+func checkFieldLengths(rec marc21.Record, rd RecordDesc) (l []Issue) {
+
+	format := rec.RecordFormat()
+
+	if fd, ok := LeaderDef(format); ok {
+		l = append(l, fieldLengthIssues("LDR", rec.Leader.Text, fd)...)
+	}
+
+	for _, cf := range rec.Controlfields {
+		if cf.Tag == "007" {
+			// See check007Length
+			continue
+		}
+		if fd, ok := controlfieldDef(format, rd.Material, cf); ok {
+			l = append(l, fieldLengthIssues(cf.Tag, cf.Text, fd)...)
+		}
+	}
+
+	return l
+}
+
+func fieldLengthIssues(tag, text string, fd FieldDef) (l []Issue) {
+
+	n := fd.Length()
+	if len(text) == n {
+		return l
+	}
+
+	what := fd.Name
+	if fd.Material != "" {
+		what += " (" + fd.Material + ")"
+	}
+
+	return append(l, Issue{
+		Tag:     tag,
+		Message: fmt.Sprintf("length is %d, expected %d for %s", len(text), n, what),
+	})
+}
+
+// checkLeaderFill reports fill characters in the leader
+func checkLeaderFill(rec marc21.Record, rd RecordDesc) (l []Issue) {
+	for i := 0; i < len(rec.Leader.Text); i++ {
+		if rec.Leader.Text[i] == '|' {
+			l = append(l, Issue{
+				Tag:       "LDR",
+				Positions: positions(i, 1),
+				Message:   "fill character in leader",
+			})
+		}
+	}
+	return l
+}
+
+// checkRecordFormat reports records whose format or material type
+// cannot be determined from the leader
+func checkRecordFormat(rec marc21.Record, rd RecordDesc) (l []Issue) {
+
+	if rd.Format == "" {
+		return append(l, Issue{
+			Tag:       "LDR",
+			Positions: "06",
+			Message:   fmt.Sprintf("type of record %q does not identify a record format", pluckByte(rec.Leader.Text, 6)),
+		})
+	}
+
+	if rec.RecordFormat() == marc21.Bibliography && rd.Material == "" {
+		l = append(l, Issue{
+			Tag:       "LDR",
+			Positions: "06-07",
+			Message:   fmt.Sprintf("type of record %q and bibliographic level %q do not identify a material type", pluckByte(rec.Leader.Text, 6), pluckByte(rec.Leader.Text, 7)),
+		})
+	}
+
+	return l
+}
+
+// checkMaterialTypes reports 006 and 007 fields whose material type
+// is not defined
+func checkMaterialTypes(rec marc21.Record, rd RecordDesc) (l []Issue) {
+
+	format := rec.RecordFormat()
+
+	for _, cf := range rec.Controlfields {
+		switch {
+		case cf.Tag == "006" && format == marc21.Bibliography:
+			if _, ok := Cf006Def(pluckByte(cf.Text, 0)); !ok {
+				l = append(l, Issue{
+					Tag:       "006",
+					Positions: "00",
+					Message:   fmt.Sprintf("undefined form of material %q", pluckByte(cf.Text, 0)),
+				})
+			}
+		case cf.Tag == "007" && (format == marc21.Bibliography || format == marc21.Holdings):
+			if _, ok := Cf007Def(format, pluckByte(cf.Text, 0)); !ok {
+				l = append(l, Issue{
+					Tag:       "007",
+					Positions: "00",
+					Message:   fmt.Sprintf("undefined category of material %q", pluckByte(cf.Text, 0)),
+				})
+			}
+		}
+	}
+
+	return l
+}
+
+// checkMissing008 reports records that have no 008
+func checkMissing008(rec marc21.Record, rd RecordDesc) (l []Issue) {
+
+	if rd.Format == "" || rec.GetControlfield("008") != "" {
+		return l
+	}
+
+	return append(l, Issue{Tag: "008", Message: "no 008 field"})
+}
+
+// isLanguageCode determines if a code has the form of a MARC language
+// code (three lower case letters)
+func isLanguageCode(s string) bool {
+	if len(s) != 3 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < 'a' || s[i] > 'z' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,109 @@
+// Copyright 2017-2018 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package details
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+
+	// A books 008 without an index so that the index-note rule does
+	// not apply
+	book008 := testBook008[:31] + "0" + testBook008[32:]
+	cr007 := "cr |||||||||||"
+
+	tests := []struct {
+		name   string
+		ldr    string
+		cfs    []string
+		config string
+		want   []string
+	}{
+		{"valid", testBookLeader, []string{"001 rec1", "008 " + book008}, "", nil},
+		{"missing 008", testBookLeader, []string{"001 rec1"}, "", []string{"missing-008 warning"}},
+		{"undefined leader code", "00428xam a2200181 i 4500", []string{"008 " + book008}, "", []string{"undefined-code"}},
+		{"undefined 008 code", testBookLeader, []string{"008 " + book008[:22] + "x" + book008[23:]}, "", []string{"undefined-code"}},
+		{"short 008", testBookLeader, []string{"008 " + book008[:30]}, "", []string{"field-length"}},
+		{"fill in leader", "00428cam a2200181|i 4500", []string{"008 " + book008}, "", []string{"fill-in-leader"}},
+		{"same 007 twice", testBookLeader, []string{"007 " + cr007, "007 " + cr007, "008 " + book008}, "", []string{"007-duplicate warning"}},
+		{"two 007 for a category", testBookLeader, []string{"007 " + cr007, "007 co |||||||||||", "008 " + book008}, "", []string{"007-duplicate warning"}},
+		{"007 for two categories", testBookLeader, []string{"007 " + cr007, "007 ta", "008 " + book008}, "", nil},
+		{"rule off", testBookLeader, []string{"001 rec1"}, "missing-008 off", nil},
+		{"rule severity", testBookLeader, []string{"008 " + book008[:30]}, "# comment\n\nfield-length warning\n", []string{"field-length warning"}},
+	}
+
+	for _, tt := range tests {
+		v := NewValidator()
+		if tt.config != "" {
+			err := v.ReadConfig(strings.NewReader(tt.config))
+			if err != nil {
+				t.Errorf("%s: ReadConfig: %s", tt.name, err)
+				continue
+			}
+		}
+
+		// Rules are given with their severity unless it is error
+		var got []string
+		for _, is := range v.Validate(testRecord(tt.ldr, tt.cfs...)) {
+			s := is.Rule
+			if is.Severity != SeverityError {
+				s += " " + is.Severity.String()
+			}
+			got = append(got, s)
+		}
+
+		if strings.Join(got, ", ") != strings.Join(tt.want, ", ") {
+			t.Errorf("%s: Validate = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestReadConfig(t *testing.T) {
+
+	tests := []struct {
+		config  string
+		wantErr bool
+	}{
+		{"undefined-code warning\nfill-in-leader off\n", false},
+		{"no-such-rule warning\n", true},
+		{"undefined-code severe\n", true},
+		{"undefined-code\n", true},
+	}
+
+	for _, tt := range tests {
+		err := NewValidator().ReadConfig(strings.NewReader(tt.config))
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ReadConfig(%q) error = %v, want error %v", tt.config, err, tt.wantErr)
+		}
+	}
+}
+
+func Test007CategoryLabels(t *testing.T) {
+
+	tests := []struct {
+		name string
+		ldr  string
+		cfs  []string
+		want string
+	}{
+		{"bibliography", testBookLeader, []string{"007 ta", "007 ta", "008 " + testBook008}, `Category of material "t" (Text)`},
+		{"holdings", "00000nx  a2200000   4500", []string{"007 ta", "007 ta"}, `Category of material "t" (Text)`},
+		{"community", "00000nq  a2200000   4500", []string{"007 e", "007 e"}, `Category of material "e" (Disabled)`},
+	}
+
+	for _, tt := range tests {
+		var found bool
+		for _, is := range NewValidator().Validate(testRecord(tt.ldr, tt.cfs...)) {
+			if is.Tag == "007" && strings.Contains(is.Message, tt.want) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("%s: no 007 issue mentions %s", tt.name, tt.want)
+		}
+	}
+}